@@ -0,0 +1,254 @@
+package chime
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// types of chime wired to doorbell
+const (
+	TypeMechanical = "mechanical"
+	TypeDigital    = "digital"
+)
+
+// Types is list of chime types
+var Types = []string{TypeMechanical, TypeDigital}
+
+var (
+	// ErrNoChime is returned if doorbell can't drive chime
+	ErrNoChime = xerrors.New("doorbell has no chime")
+	// ErrInvalidSettings is returned if chime settings are invalid
+	ErrInvalidSettings = xerrors.New("invalid chime settings")
+)
+
+// Controller changes chime wired to doorbells and disables it during DND hours
+type Controller struct {
+	r      Registry
+	c      Configuration
+	logger logrus.FieldLogger
+
+	// mu serializes changes of chime and its state file
+	mu sync.Mutex
+}
+
+type Registry interface {
+	AppLogger(app string) logrus.FieldLogger
+	UnifiClient() *unifi.Client
+}
+
+type Configuration interface {
+	ChimeDNDEnabled() bool
+	ChimeDNDStart() string
+	ChimeDNDEnd() string
+	ChimeStateFile() string
+}
+
+func New(r Registry, c Configuration) *Controller {
+	return &Controller{
+		r:      r,
+		c:      c,
+		logger: r.AppLogger("chime"),
+	}
+}
+
+// Status is chime of a doorbell
+type Status struct {
+	DoorbellID   string `json:"doorbell_id"`
+	DoorbellName string `json:"doorbell_name"`
+	Enabled      bool   `json:"enabled"`
+	// Type and DurationMs are the ones restored when chime is enabled again if it is disabled.
+	// They are empty if chime has never been enabled.
+	Type       string    `json:"type,omitempty"`
+	DurationMs int       `json:"duration_ms,omitempty"`
+	DND        DNDStatus `json:"dnd"`
+}
+
+// Settings changes chime. Nil fields are left unchanged.
+type Settings struct {
+	Enabled *bool   `json:"enabled,omitempty"`
+	Type    *string `json:"type,omitempty"`
+	// DurationMs is how long digital chime rings
+	DurationMs *int `json:"duration_ms,omitempty"`
+}
+
+// typeOf returns type of chime duration of UniFi Protect
+func typeOf(durationMs int) string {
+	switch durationMs {
+	case unifi.ChimeDurationNone:
+		return ""
+	case unifi.ChimeDurationMechanical:
+		return TypeMechanical
+	default:
+		return TypeDigital
+	}
+}
+
+// Status returns chime of the doorbell
+func (c *Controller) Status(ctx context.Context, doorbellID string) (*Status, error) {
+	d, err := c.doorbell(ctx, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+	st, err := loadState(c.c.ChimeStateFile())
+	if err != nil {
+		return nil, err
+	}
+	return c.status(d, st), nil
+}
+
+func (c *Controller) status(d *unifi.Doorbell, st *state) *Status {
+	durationMs := d.ChimeDuration
+	if durationMs == unifi.ChimeDurationNone {
+		durationMs = st.Doorbells[d.ID].DurationMs
+	}
+	return &Status{
+		DoorbellID:   d.ID,
+		DoorbellName: d.Name,
+		Enabled:      d.ChimeDuration != unifi.ChimeDurationNone,
+		Type:         typeOf(durationMs),
+		DurationMs:   durationMs,
+		DND:          c.dndStatus(time.Now()),
+	}
+}
+
+func (c *Controller) doorbell(ctx context.Context, doorbellID string) (*unifi.Doorbell, error) {
+	d, err := c.r.UnifiClient().Cache().Doorbell(ctx, doorbellID, unifi.DefaultMaxAge)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get chime: %w", err)
+	}
+	if !d.FeatureFlags.HasChime {
+		return nil, xerrors.Errorf("%s: %w", d.Name, ErrNoChime)
+	}
+	return d, nil
+}
+
+// Set changes chime of the doorbell. Change during DND hours is kept until they end.
+func (c *Controller) Set(ctx context.Context, doorbellID string, s Settings) (*Status, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	d, err := c.doorbell(ctx, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+	st, err := loadState(c.c.ChimeStateFile())
+	if err != nil {
+		return nil, err
+	}
+	current := c.status(d, st)
+
+	enabled := current.Enabled
+	if s.Enabled != nil {
+		enabled = *s.Enabled
+	}
+	durationMs, err := resolveDuration(current, s)
+	if err != nil {
+		return nil, err
+	}
+	if enabled && durationMs == unifi.ChimeDurationNone {
+		return nil, xerrors.Errorf("type is required to enable chime which has never been enabled: %w", ErrInvalidSettings)
+	}
+
+	target := unifi.ChimeDurationNone
+	if enabled {
+		target = durationMs
+	}
+	if target != d.ChimeDuration {
+		if d, err = c.r.UnifiClient().SetChimeDuration(ctx, d.ID, target); err != nil {
+			return nil, xerrors.Errorf("failed to set chime: %w", err)
+		}
+	}
+
+	ds := st.Doorbells[d.ID]
+	ds.DurationMs = durationMs
+	ds.DisabledByDND = false
+	ds.DNDOverridden = current.DND.Active
+	st.Doorbells[d.ID] = ds
+	if err := st.save(c.c.ChimeStateFile()); err != nil {
+		return nil, err
+	}
+
+	c.logger.Infof("chime of %s is changed. enabled: %t, duration: %dms", d.Name, enabled, durationMs)
+	return c.status(d, st), nil
+}
+
+// resolveDuration returns chime duration of UniFi Protect for current chime changed by s
+func resolveDuration(current *Status, s Settings) (int, error) {
+	typ := current.Type
+	if s.Type != nil {
+		typ = *s.Type
+	}
+
+	switch typ {
+	case "":
+		if s.DurationMs != nil {
+			return 0, xerrors.Errorf("type is required with duration: %w", ErrInvalidSettings)
+		}
+		return unifi.ChimeDurationNone, nil
+
+	case TypeMechanical:
+		if s.DurationMs != nil {
+			return 0, xerrors.Errorf("duration is only for digital chime: %w", ErrInvalidSettings)
+		}
+		return unifi.ChimeDurationMechanical, nil
+
+	case TypeDigital:
+		durationMs := unifi.ChimeDurationDigitalMin
+		if current.Type == TypeDigital {
+			durationMs = current.DurationMs
+		}
+		if s.DurationMs != nil {
+			durationMs = *s.DurationMs
+		}
+		if durationMs < unifi.ChimeDurationDigitalMin || durationMs > unifi.ChimeDurationDigitalMax {
+			return 0, xerrors.Errorf("duration must be between %d and %d ms: %w",
+				unifi.ChimeDurationDigitalMin, unifi.ChimeDurationDigitalMax, ErrInvalidSettings)
+		}
+		return durationMs, nil
+
+	default:
+		return 0, xerrors.Errorf("unknown type %q. use mechanical or digital: %w", typ, ErrInvalidSettings)
+	}
+}
+
+// Test rings chime of the doorbell
+func (c *Controller) Test(ctx context.Context, doorbellID string) error {
+	d, err := c.doorbell(ctx, doorbellID)
+	if err != nil {
+		return err
+	}
+	if err := c.r.UnifiClient().PlayChime(ctx, d.ID); err != nil {
+		return xerrors.Errorf("failed to test chime of %s: %w", d.Name, err)
+	}
+	c.logger.Infof("chime of %s is tested", d.Name)
+	return nil
+}
+
+// interval to check whether DND hours start or end
+const dndCheckInterval = 30 * time.Second
+
+// Start disables chimes during DND hours until ctx is done
+func (c *Controller) Start(ctx context.Context) error {
+	defer c.logger.Info("Bye!")
+
+	ticker := time.NewTicker(dndCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		// UniFi Protect may be unreachable for a while. retry on next tick
+		if err := c.applyDND(ctx); err != nil && ctx.Err() == nil {
+			c.logger.Warn(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package chime
+
+import (
+	"testing"
+
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"golang.org/x/xerrors"
+)
+
+func TestResolveDuration(t *testing.T) {
+	str := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+
+	never := &Status{}
+	mechanical := &Status{Type: TypeMechanical, DurationMs: unifi.ChimeDurationMechanical}
+	digital := &Status{Type: TypeDigital, DurationMs: 3000}
+
+	for _, tc := range []struct {
+		name     string
+		current  *Status
+		settings Settings
+		want     int
+		invalid  bool
+	}{
+		{name: "never enabled", current: never, want: unifi.ChimeDurationNone},
+		{name: "duration without type", current: never, settings: Settings{DurationMs: num(2000)}, invalid: true},
+		{name: "keep mechanical", current: mechanical, want: unifi.ChimeDurationMechanical},
+		{name: "mechanical with duration", current: mechanical, settings: Settings{DurationMs: num(2000)}, invalid: true},
+		{name: "digital from mechanical", current: mechanical, settings: Settings{Type: str(TypeDigital)}, want: unifi.ChimeDurationDigitalMin},
+		{name: "keep digital duration", current: digital, want: 3000},
+		{name: "change digital duration", current: digital, settings: Settings{DurationMs: num(5000)}, want: 5000},
+		{name: "mechanical from digital", current: digital, settings: Settings{Type: str(TypeMechanical)}, want: unifi.ChimeDurationMechanical},
+		{name: "too short", current: digital, settings: Settings{DurationMs: num(unifi.ChimeDurationDigitalMin - 1)}, invalid: true},
+		{name: "too long", current: digital, settings: Settings{DurationMs: num(unifi.ChimeDurationDigitalMax + 1)}, invalid: true},
+		{name: "unknown type", current: digital, settings: Settings{Type: str("bell")}, invalid: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolveDuration(tc.current, tc.settings)
+			if tc.invalid {
+				if !xerrors.Is(err, ErrInvalidSettings) {
+					t.Errorf("got %d, %v, want invalid settings", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package chime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"golang.org/x/xerrors"
+)
+
+// ParseClock parses time of day formatted as HH:MM and returns minutes since midnight
+func ParseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, xerrors.Errorf("invalid time %q. use HH:MM like 22:00", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// InWindow reports whether now is between start and end formatted as HH:MM.
+// Window across midnight like 22:00 to 07:00 is supported. Window whose start equals end is empty.
+func InWindow(now time.Time, start, end string) (bool, error) {
+	s, err := ParseClock(start)
+	if err != nil {
+		return false, err
+	}
+	e, err := ParseClock(end)
+	if err != nil {
+		return false, err
+	}
+
+	m := now.Hour()*60 + now.Minute()
+	if s <= e {
+		return s <= m && m < e, nil
+	}
+	return m >= s || m < e, nil
+}
+
+// DNDStatus is do not disturb hours of chime
+type DNDStatus struct {
+	Enabled bool   `json:"enabled"`
+	Start   string `json:"start,omitempty"`
+	End     string `json:"end,omitempty"`
+	// Active is true if it is DND hours now
+	Active bool `json:"active"`
+}
+
+func (c *Controller) dndStatus(now time.Time) DNDStatus {
+	s := DNDStatus{
+		Enabled: c.c.ChimeDNDEnabled(),
+	}
+	if s.Enabled {
+		s.Start = c.c.ChimeDNDStart()
+		s.End = c.c.ChimeDNDEnd()
+		active, err := InWindow(now, s.Start, s.End)
+		if err != nil {
+			// config validation rejects it, so it is never active rather than failing every check
+			c.logger.Warnf("DND is ignored: %s", err)
+		}
+		s.Active = active
+	}
+	return s
+}
+
+// applyDND disables chimes when DND starts and enables them again when it ends
+func (c *Controller) applyDND(ctx context.Context) error {
+	active := c.dndStatus(time.Now()).Active
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	st, err := loadState(c.c.ChimeStateFile())
+	if err != nil {
+		return err
+	}
+	// nothing to do outside DND unless something is disabled by it
+	if !active && !st.hasDND() {
+		return nil
+	}
+
+	doorbells, err := c.r.UnifiClient().Cache().Doorbells(ctx, unifi.DefaultMaxAge)
+	if err != nil {
+		return xerrors.Errorf("failed to apply DND: %w", err)
+	}
+
+	// other doorbells are applied even if one fails. failed ones are retried on next check
+	changed := false
+	for _, d := range doorbells {
+		if !d.FeatureFlags.HasChime {
+			continue
+		}
+		ds := st.Doorbells[d.ID]
+
+		switch {
+		case active && d.ChimeDuration != unifi.ChimeDurationNone && !ds.DisabledByDND && !ds.DNDOverridden:
+			if _, err := c.r.UnifiClient().SetChimeDuration(ctx, d.ID, unifi.ChimeDurationNone); err != nil {
+				c.logger.Errorf("failed to disable chime of %s: %s", d.Name, err)
+				continue
+			}
+			c.logger.Infof("chime of %s is disabled until %s", d.Name, c.c.ChimeDNDEnd())
+			ds.DurationMs = d.ChimeDuration
+			ds.DisabledByDND = true
+
+		case !active && ds.DisabledByDND:
+			if d.ChimeDuration == unifi.ChimeDurationNone && ds.DurationMs != unifi.ChimeDurationNone {
+				if _, err := c.r.UnifiClient().SetChimeDuration(ctx, d.ID, ds.DurationMs); err != nil {
+					c.logger.Errorf("failed to enable chime of %s: %s", d.Name, err)
+					continue
+				}
+				c.logger.Infof("chime of %s is enabled again", d.Name)
+			}
+			ds.DisabledByDND = false
+			ds.DNDOverridden = false
+
+		case !active && ds.DNDOverridden:
+			ds.DNDOverridden = false
+
+		default:
+			continue
+		}
+		st.Doorbells[d.ID] = ds
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return st.save(c.c.ChimeStateFile())
+}
+
+func (s *state) hasDND() bool {
+	for _, ds := range s.Doorbells {
+		if ds.DisabledByDND || ds.DNDOverridden {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns DND hours like 22:00-07:00
+func (s DNDStatus) String() string {
+	if !s.Enabled {
+		return "off"
+	}
+	return fmt.Sprintf("%s-%s", s.Start, s.End)
+}
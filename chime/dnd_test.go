@@ -0,0 +1,229 @@
+package chime
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"github.com/sirupsen/logrus"
+)
+
+func TestInWindow(t *testing.T) {
+	at := func(clock string) time.Time {
+		t, _ := time.Parse("15:04", clock)
+		return t
+	}
+
+	for _, tc := range []struct {
+		start, end string
+		now        string
+		want       bool
+	}{
+		// same day
+		{start: "09:00", end: "17:00", now: "08:59", want: false},
+		{start: "09:00", end: "17:00", now: "09:00", want: true},
+		{start: "09:00", end: "17:00", now: "16:59", want: true},
+		{start: "09:00", end: "17:00", now: "17:00", want: false},
+		// across midnight
+		{start: "22:00", end: "07:00", now: "21:59", want: false},
+		{start: "22:00", end: "07:00", now: "22:00", want: true},
+		{start: "22:00", end: "07:00", now: "00:00", want: true},
+		{start: "22:00", end: "07:00", now: "06:59", want: true},
+		{start: "22:00", end: "07:00", now: "07:00", want: false},
+		{start: "22:00", end: "07:00", now: "12:00", want: false},
+		// start == end is empty
+		{start: "07:00", end: "07:00", now: "07:00", want: false},
+		{start: "07:00", end: "07:00", now: "19:00", want: false},
+	} {
+		got, err := InWindow(at(tc.now), tc.start, tc.end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("%s in %s-%s: got %t, want %t", tc.now, tc.start, tc.end, got, tc.want)
+		}
+	}
+
+	for _, window := range [][2]string{{"10pm", "07:00"}, {"22:00", "7"}, {"", "07:00"}} {
+		if _, err := InWindow(at("12:00"), window[0], window[1]); err == nil {
+			t.Errorf("%s-%s is not rejected", window[0], window[1])
+		}
+	}
+}
+
+type registry struct {
+	client *unifi.Client
+}
+
+func (registry) AppLogger(string) logrus.FieldLogger {
+	l := logrus.New()
+	l.SetOutput(ioutil.Discard)
+	return l
+}
+
+func (r registry) UnifiClient() *unifi.Client {
+	return r.client
+}
+
+// configuration has DND hours around now or after now
+type configuration struct {
+	mu        sync.Mutex
+	dndActive bool
+	stateFile string
+}
+
+func (c *configuration) setDND(active bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dndActive = active
+}
+
+func (c *configuration) window() (string, string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if c.dndActive {
+		return now.Add(-time.Hour).Format("15:04"), now.Add(time.Hour).Format("15:04")
+	}
+	return now.Add(time.Hour).Format("15:04"), now.Add(2 * time.Hour).Format("15:04")
+}
+
+func (c *configuration) ChimeDNDEnabled() bool  { return true }
+func (c *configuration) ChimeDNDStart() string  { s, _ := c.window(); return s }
+func (c *configuration) ChimeDNDEnd() string    { _, e := c.window(); return e }
+func (c *configuration) ChimeStateFile() string { return c.stateFile }
+
+type unifiConfiguration struct{}
+
+func (unifiConfiguration) UnifiIp() string                { return "127.0.0.1" }
+func (unifiConfiguration) UnifiUsername() string          { return "doorbell" }
+func (unifiConfiguration) UnifiPassword() (string, error) { return "", nil }
+func (unifiConfiguration) DoorbellsInclude() []string     { return nil }
+func (unifiConfiguration) DoorbellsExclude() []string     { return nil }
+
+// fakeProtect serves a doorbell with chime and records chime changed by PATCH
+type fakeProtect struct {
+	mu       sync.Mutex
+	duration int
+}
+
+func (f *fakeProtect) chimeDuration() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.duration
+}
+
+func (f *fakeProtect) camera() map[string]interface{} {
+	return map[string]interface{}{
+		"id":            "door",
+		"name":          "Front Door",
+		"isManaged":     true,
+		"chimeDuration": f.duration,
+		"featureFlags":  map[string]interface{}{"isDoorbell": true, "hasChime": true},
+	}
+}
+
+func (f *fakeProtect) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/bootstrap":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"cameras": []interface{}{f.camera()}})
+	case r.Method == http.MethodPatch && r.URL.Path == "/api/cameras/door":
+		var patch struct {
+			ChimeDuration int `json:"chimeDuration"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.duration = patch.ChimeDuration
+		_ = json.NewEncoder(w).Encode(f.camera())
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestController(t *testing.T, duration int) (*Controller, *configuration, *fakeProtect) {
+	t.Helper()
+	protect := &fakeProtect{duration: duration}
+	svr := httptest.NewTLSServer(protect)
+	t.Cleanup(svr.Close)
+
+	// every request is sent to the fake whatever address UniFi Protect has
+	httpclient := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, svr.Listener.Addr().String())
+			},
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	r := registry{}
+	r.client = unifi.NewClient(r, unifiConfiguration{}, httpclient)
+	c := &configuration{stateFile: filepath.Join(t.TempDir(), "chime.json")}
+	return New(r, c), c, protect
+}
+
+func applyDND(t *testing.T, ctrl *Controller) {
+	t.Helper()
+	if err := ctrl.applyDND(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDNDRestoresChime(t *testing.T) {
+	ctrl, c, protect := newTestController(t, unifi.ChimeDurationMechanical)
+
+	c.setDND(true)
+	applyDND(t, ctrl)
+	if got := protect.chimeDuration(); got != unifi.ChimeDurationNone {
+		t.Fatalf("chime is %dms during DND", got)
+	}
+
+	c.setDND(false)
+	applyDND(t, ctrl)
+	if got := protect.chimeDuration(); got != unifi.ChimeDurationMechanical {
+		t.Errorf("chime is %dms after DND, want restored", got)
+	}
+}
+
+func TestDNDOverrideExpires(t *testing.T) {
+	ctrl, c, protect := newTestController(t, 3000)
+
+	c.setDND(true)
+	applyDND(t, ctrl)
+
+	// enabled manually during DND
+	enabled := true
+	if _, err := ctrl.Set(context.Background(), "door", Settings{Enabled: &enabled}); err != nil {
+		t.Fatal(err)
+	}
+	applyDND(t, ctrl)
+	if got := protect.chimeDuration(); got != 3000 {
+		t.Fatalf("chime enabled manually is %dms during DND", got)
+	}
+
+	c.setDND(false)
+	applyDND(t, ctrl)
+	if got := protect.chimeDuration(); got != 3000 {
+		t.Fatalf("chime enabled manually is %dms after DND", got)
+	}
+
+	// override is only for the DND hours it is made in
+	c.setDND(true)
+	applyDND(t, ctrl)
+	if got := protect.chimeDuration(); got != unifi.ChimeDurationNone {
+		t.Errorf("chime is %dms on next DND, want disabled", got)
+	}
+}
@@ -0,0 +1,57 @@
+package chime
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+)
+
+// doorbellState is what controller remembers about chime of a doorbell
+type doorbellState struct {
+	// DurationMs is chime duration to restore when chime is enabled again
+	DurationMs int `json:"duration_ms,omitempty"`
+	// DisabledByDND is true if chime is disabled by DND and enabled when it ends
+	DisabledByDND bool `json:"disabled_by_dnd,omitempty"`
+	// DNDOverridden is true if chime is changed manually during DND, which leaves it as it is until DND ends
+	DNDOverridden bool `json:"dnd_overridden,omitempty"`
+}
+
+// state is persisted so that chime disabled by DND is restored after restart
+type state struct {
+	Doorbells map[string]doorbellState `json:"doorbells"`
+}
+
+func loadState(path string) (*state, error) {
+	s := &state{Doorbells: make(map[string]doorbellState)}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read chime state: %w", err)
+	}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, xerrors.Errorf("failed to decode chime state %s: %w", path, err)
+	}
+	if s.Doorbells == nil {
+		s.Doorbells = make(map[string]doorbellState)
+	}
+	return s, nil
+}
+
+func (s *state) save(path string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("failed to encode chime state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return xerrors.Errorf("failed to create directory of chime state: %w", err)
+	}
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		return xerrors.Errorf("failed to write chime state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package clip
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/sawadashota/unifi-doorbell-chime/event"
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// Exporter saves recorded video around each ring and snapshot of each delivered package
+type Exporter struct {
+	r      Registry
+	c      Configuration
+	logger logrus.FieldLogger
+	events *event.Hub
+
+	mu    sync.RWMutex
+	store *Store
+}
+
+type Registry interface {
+	AppLogger(app string) logrus.FieldLogger
+	UnifiClient() *unifi.Client
+	EventHub() *event.Hub
+}
+
+type Configuration interface {
+	ClipEnabled() bool
+	ClipDir() string
+	ClipMaxDiskUsageMB() int
+	ClipPreSec() int
+	ClipPostSec() int
+	Subscribe(fn func())
+}
+
+// margin to wait for NVR to finish writing recording
+const recordingMargin = 5 * time.Second
+
+func New(r Registry, c Configuration) *Exporter {
+	e := &Exporter{
+		r:      r,
+		c:      c,
+		logger: r.AppLogger("clip"),
+		events: r.EventHub(),
+	}
+	e.onConfigReloaded()
+	c.Subscribe(e.onConfigReloaded)
+	return e
+}
+
+func (e *Exporter) onConfigReloaded() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.store = NewStore(e.c.ClipDir(), int64(e.c.ClipMaxDiskUsageMB())<<20)
+}
+
+func (e *Exporter) Store() *Store {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.store
+}
+
+func (e *Exporter) Start(ctx context.Context) error {
+	defer e.logger.Info("Bye!")
+
+	events, unsubscribe := e.events.Subscribe()
+	defer unsubscribe()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case ev, ok := <-events:
+			if !ok {
+				return nil
+			}
+			// synthetic ring has no recording to export
+			if ev.Synthetic {
+				continue
+			}
+			// package snapshot doesn't need recording, so it is saved even if clip is disabled
+			if ev.Type == event.TypePackage {
+				if ev.Package != event.PackageDelivered {
+					continue
+				}
+				// snapshot is taken right away while package is in view
+				e.run(ctx, &wg, ev, e.savePackageSnapshot)
+				continue
+			}
+			if ev.Type != event.TypeRing || !e.c.ClipEnabled() {
+				continue
+			}
+			e.run(ctx, &wg, ev, e.export)
+		}
+	}
+}
+
+// run saves ev by save in background not to miss events delivered meanwhile
+func (e *Exporter) run(ctx context.Context, wg *sync.WaitGroup, ev event.Event, save func(context.Context, event.Event) error) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := save(ctx, ev); err != nil {
+			if xerrors.Is(err, context.Canceled) {
+				return
+			}
+			e.logger.Error(err)
+		}
+	}()
+}
+
+func (e *Exporter) export(ctx context.Context, ev event.Event) error {
+	start := ev.Time.Add(-time.Duration(e.c.ClipPreSec()) * time.Second)
+	end := ev.Time.Add(time.Duration(e.c.ClipPostSec()) * time.Second)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(end.Add(recordingMargin))):
+	}
+
+	bc := backoff.NewExponentialBackOff()
+	bc.MaxElapsedTime = time.Minute
+	bc.Reset()
+
+	err := backoff.Retry(func() error {
+		d, err := e.r.UnifiClient().Cache().Doorbell(ctx, ev.DoorbellID, unifi.DefaultMaxAge)
+		if err != nil {
+			return xerrors.Errorf("failed to get doorbell: %w", err)
+		}
+		if d.RecordingSettings.Mode == "never" {
+			return backoff.Permanent(xerrors.Errorf("recording of %s is disabled", d.Name))
+		}
+		if d.Stats.Video.RecordingEnd*int64(time.Millisecond) < end.UnixNano() {
+			return xerrors.Errorf("recording of %s is not available until %s yet", d.Name, end)
+		}
+		return nil
+	}, backoff.WithContext(bc, ctx))
+	if err != nil {
+		return xerrors.Errorf("failed to export clip of %s: %w", ev.ID, err)
+	}
+
+	if err := e.Store().Save(ev, func(w io.Writer) error {
+		return e.r.UnifiClient().ExportVideo(ctx, w, ev.DoorbellID, start, end)
+	}); err != nil {
+		return xerrors.Errorf("failed to export clip of %s: %w", ev.ID, err)
+	}
+
+	e.logger.Infof("saved clip of %s ring at %s", ev.DoorbellName, ev.Time.Format(time.RFC3339))
+	return nil
+}
+
+func (e *Exporter) savePackageSnapshot(ctx context.Context, ev event.Event) error {
+	if err := e.Store().SaveSnapshot(ev, func(w io.Writer) error {
+		return e.r.UnifiClient().GetPackageSnapshot(ctx, w, ev.DoorbellID)
+	}); err != nil {
+		return xerrors.Errorf("failed to save package snapshot of %s: %w", ev.ID, err)
+	}
+
+	e.logger.Infof("saved package snapshot of %s at %s", ev.DoorbellName, ev.Time.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,173 @@
+package clip
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sawadashota/unifi-doorbell-chime/event"
+	"golang.org/x/xerrors"
+)
+
+var (
+	ErrNotFound         = xerrors.New("clip is not found")
+	ErrSnapshotNotFound = xerrors.New("snapshot is not found")
+	ErrInvalidID        = xerrors.New("invalid event ID")
+
+	eventIDPattern = regexp.MustCompile(`^[0-9a-f]+$`)
+)
+
+const (
+	clipExt     = ".mp4"
+	snapshotExt = ".jpg"
+	recordExt   = ".json"
+)
+
+// Store saves clips as <event ID>.mp4 and package snapshots as <event ID>.jpg
+// with the event as <event ID>.json next to them
+type Store struct {
+	dir      string
+	maxBytes int64
+	mu       sync.Mutex
+}
+
+func NewStore(dir string, maxBytes int64) *Store {
+	return &Store{
+		dir:      dir,
+		maxBytes: maxBytes,
+	}
+}
+
+func (s *Store) path(eventID, ext string) (string, error) {
+	if !eventIDPattern.MatchString(eventID) {
+		return "", ErrInvalidID
+	}
+	return filepath.Join(s.dir, eventID+ext), nil
+}
+
+// Save writes the clip by write function and removes old clips exceeding disk usage limit
+func (s *Store) Save(e event.Event, write func(w io.Writer) error) error {
+	return s.save(e, clipExt, write)
+}
+
+// SaveSnapshot writes the package snapshot by write function and removes old files exceeding disk usage limit
+func (s *Store) SaveSnapshot(e event.Event, write func(w io.Writer) error) error {
+	return s.save(e, snapshotExt, write)
+}
+
+func (s *Store) save(e event.Event, ext string, write func(w io.Writer) error) error {
+	mediaPath, err := s.path(e.ID, ext)
+	if err != nil {
+		return err
+	}
+	recordPath, err := s.path(e.ID, recordExt)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return xerrors.Errorf("failed to create clip directory: %w", err)
+	}
+
+	tmp, err := ioutil.TempFile(s.dir, e.ID+"-*.tmp")
+	if err != nil {
+		return xerrors.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if err := write(tmp); err != nil {
+		_ = tmp.Close()
+		return xerrors.Errorf("failed to write %s: %w", ext, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return xerrors.Errorf("failed to close %s: %w", ext, err)
+	}
+
+	record, err := json.Marshal(&e)
+	if err != nil {
+		return xerrors.Errorf("failed to encode event: %w", err)
+	}
+	if err := ioutil.WriteFile(recordPath, record, 0600); err != nil {
+		return xerrors.Errorf("failed to write event record: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), mediaPath); err != nil {
+		return xerrors.Errorf("failed to save %s: %w", ext, err)
+	}
+
+	return s.enforceRetention()
+}
+
+// Open returns the clip of the event
+func (s *Store) Open(eventID string) (*os.File, error) {
+	return s.open(eventID, clipExt, ErrNotFound)
+}
+
+// OpenSnapshot returns the package snapshot of the event
+func (s *Store) OpenSnapshot(eventID string) (*os.File, error) {
+	return s.open(eventID, snapshotExt, ErrSnapshotNotFound)
+}
+
+// open returns the file of the event with ext, or notFound if it doesn't exist
+func (s *Store) open(eventID, ext string, notFound error) (*os.File, error) {
+	p, err := s.path(eventID, ext)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, notFound
+		}
+		return nil, xerrors.Errorf("failed to open %s: %w", ext, err)
+	}
+	return f, nil
+}
+
+// enforceRetention removes clips and snapshots from the oldest until total size gets under the limit
+func (s *Store) enforceRetention() error {
+	if s.maxBytes <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	infos, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return xerrors.Errorf("failed to read clip directory: %w", err)
+	}
+
+	var clips []os.FileInfo
+	var total int64
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), clipExt) && !strings.HasSuffix(info.Name(), snapshotExt) {
+			continue
+		}
+		clips = append(clips, info)
+		total += info.Size()
+	}
+	sort.Slice(clips, func(i, j int) bool {
+		return clips[i].ModTime().Before(clips[j].ModTime())
+	})
+
+	for _, c := range clips {
+		if total <= s.maxBytes {
+			break
+		}
+		id := strings.TrimSuffix(c.Name(), filepath.Ext(c.Name()))
+		if err := os.Remove(filepath.Join(s.dir, c.Name())); err != nil {
+			return xerrors.Errorf("failed to remove old clip: %w", err)
+		}
+		_ = os.Remove(filepath.Join(s.dir, id+recordExt))
+		total -= c.Size()
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sawadashota/unifi-doorbell-chime/event"
+	"github.com/sawadashota/unifi-doorbell-chime/web/api/client"
+	"golang.org/x/xerrors"
+)
+
+// flags to use API of running instance instead of UniFi Protect
+var (
+	apiServer string
+	apiToken  string
+)
+
+// useAPI reports whether doorbells commands go through API of running instance
+func useAPI() bool {
+	return apiServer != ""
+}
+
+func newAPIClient() (*client.ClientWithResponses, error) {
+	c, err := client.New(apiServer, apiToken)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid API server %q: %w", apiServer, err)
+	}
+	return c, nil
+}
+
+// findAPIDoorbell finds doorbell by ID or name via API
+func findAPIDoorbell(ctx context.Context, c *client.ClientWithResponses, key string) (*client.Doorbell, error) {
+	res, err := c.ListDoorbellsWithResponse(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list doorbells: %w", err)
+	}
+	if err := client.CheckResponse(res.HTTPResponse, res.Body, http.StatusOK); err != nil {
+		return nil, xerrors.Errorf("failed to list doorbells: %w", err)
+	}
+	for _, d := range res.JSON200.Doorbells {
+		if d.Id == key || d.Name == key {
+			return &d, nil
+		}
+	}
+	return nil, xerrors.Errorf("doorbell %s is not found", key)
+}
+
+func listAPIDoorbells(ctx context.Context) error {
+	c, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	res, err := c.ListDoorbellsWithResponse(ctx)
+	if err != nil {
+		return xerrors.Errorf("failed to list doorbells: %w", err)
+	}
+	if err := client.CheckResponse(res.HTTPResponse, res.Body, http.StatusOK); err != nil {
+		return xerrors.Errorf("failed to list doorbells: %w", err)
+	}
+
+	if output == outputJSON {
+		return printJSON(os.Stdout, res.JSON200.Doorbells)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tID\tMODEL\tMAC\tSTATE\tFIRMWARE\tLAST RING")
+	for _, d := range res.JSON200.Doorbells {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			d.Name, d.Id, d.Model, d.Mac, d.State, d.Firmware, formatUnixMilli(d.LastRing))
+	}
+	return tw.Flush()
+}
+
+func saveAPISnapshot(ctx context.Context, key, path string, pkg bool) error {
+	c, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	d, err := findAPIDoorbell(ctx, c, key)
+	if err != nil {
+		return err
+	}
+
+	var body []byte
+	if pkg {
+		res, err := c.GetPackageSnapshotWithResponse(ctx, client.DoorbellID(d.Id))
+		if err != nil {
+			return xerrors.Errorf("failed to get package snapshot: %w", err)
+		}
+		if err := client.CheckResponse(res.HTTPResponse, res.Body, http.StatusOK); err != nil {
+			return xerrors.Errorf("failed to get package snapshot: %w", err)
+		}
+		body = res.Body
+	} else {
+		res, err := c.GetSnapshotWithResponse(ctx, client.DoorbellID(d.Id))
+		if err != nil {
+			return xerrors.Errorf("failed to get snapshot: %w", err)
+		}
+		if err := client.CheckResponse(res.HTTPResponse, res.Body, http.StatusOK); err != nil {
+			return xerrors.Errorf("failed to get snapshot: %w", err)
+		}
+		body = res.Body
+	}
+
+	if path == "" {
+		path = snapshotFileName(d.Id, pkg)
+	}
+	if path == "-" {
+		_, err := os.Stdout.Write(body)
+		return err
+	}
+	if err := ioutil.WriteFile(path, body, 0644); err != nil {
+		return xerrors.Errorf("failed to write %s: %w", path, err)
+	}
+	fmt.Fprintf(os.Stderr, "saved snapshot of %s to %s\n", d.Name, path)
+	return nil
+}
+
+func setAPIMessage(ctx context.Context, key, message string) error {
+	c, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	d, err := findAPIDoorbell(ctx, c, key)
+	if err != nil {
+		return err
+	}
+
+	durationSec := int(messageDuration.Seconds())
+	res, err := c.SetMessageWithResponse(ctx, client.SetMessageJSONRequestBody{
+		DoorbellId:  d.Id,
+		Message:     message,
+		DurationSec: &durationSec,
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to set message: %w", err)
+	}
+	if err := client.CheckResponse(res.HTTPResponse, res.Body, http.StatusCreated); err != nil {
+		return xerrors.Errorf("failed to set message: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "set %q on %s for %s\n", message, d.Name, messageDuration)
+	return nil
+}
+
+// watchAPIEvents prints events pushed by API as Server-Sent Events until ctx is done
+func watchAPIEvents(ctx context.Context, tw *tabwriter.Writer) error {
+	c, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	// response body is streamed. don't use WithResponse which reads it all
+	res, err := c.StreamEvents(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return xerrors.Errorf("failed to stream events: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(res.Body)
+		return xerrors.Errorf("failed to stream events: %w", client.CheckResponse(res, body, http.StatusOK))
+	}
+
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		var e event.Event
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &e); err != nil {
+			return xerrors.Errorf("failed to decode event: %w", err)
+		}
+		if err := printEvent(tw, e); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return xerrors.Errorf("event stream is closed: %w", err)
+	}
+	return xerrors.New("event stream is closed by server")
+}
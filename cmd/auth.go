@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sawadashota/unifi-doorbell-chime/x/apiauth"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	authTokenName   string
+	authTokenScopes []string
+	authUserScopes  []string
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Generate credentials of API",
+}
+
+var authTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Generate API token and print config to add to api.auth.tokens",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if authTokenName == "" {
+			return xerrors.New("--name is required")
+		}
+		if _, err := apiauth.ParseScopes(authTokenScopes); err != nil {
+			return err
+		}
+
+		token, err := apiauth.GenerateToken()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "token (shown only once): %s\n\n", token)
+		fmt.Fprintln(os.Stderr, "add following to api.auth.tokens of config")
+		return yaml.NewEncoder(os.Stdout).Encode([]map[string]interface{}{{
+			"name":   authTokenName,
+			"hash":   apiauth.HashToken(token),
+			"scopes": authTokenScopes,
+		}})
+	},
+}
+
+var authHashPasswordCmd = &cobra.Command{
+	Use:   "hash-password <username>",
+	Short: "Hash password of frontend user and print config to add to api.auth.users",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := apiauth.ParseScopes(authUserScopes); err != nil {
+			return err
+		}
+
+		password, err := newPrompter().askPassword("Password")
+		if err != nil {
+			return err
+		}
+		if password == "" {
+			return xerrors.New("password must not be empty")
+		}
+		hash, err := apiauth.HashPassword(password)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, "add following to api.auth.users of config")
+		return yaml.NewEncoder(os.Stdout).Encode([]map[string]interface{}{{
+			"username":      args[0],
+			"password_hash": hash,
+			"scopes":        authUserScopes,
+		}})
+	},
+}
+
+func init() {
+	authTokenCmd.Flags().StringVar(&authTokenName, "name", "", "Name of token such as client using it")
+	authTokenCmd.Flags().StringSliceVar(&authTokenScopes, "scope", []string{string(apiauth.ScopeRead)}, "Scopes of token. read, message, settings or admin")
+	authHashPasswordCmd.Flags().StringSliceVar(&authUserScopes, "scope", []string{string(apiauth.ScopeRead), string(apiauth.ScopeMessage)}, "Scopes of user. read, message, settings or admin")
+
+	authCmd.AddCommand(authTokenCmd)
+	authCmd.AddCommand(authHashPasswordCmd)
+	rootCmd.AddCommand(authCmd)
+}
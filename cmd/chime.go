@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sawadashota/unifi-doorbell-chime/chime"
+	"github.com/sawadashota/unifi-doorbell-chime/web/api/client"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var (
+	chimeType     string
+	chimeDuration time.Duration
+)
+
+var doorbellsChimeCmd = &cobra.Command{
+	Use:   "chime",
+	Short: "Control chime wired to doorbell",
+}
+
+var doorbellsChimeStatusCmd = &cobra.Command{
+	Use:   "status <doorbell ID or name>",
+	Short: "Show chime of doorbell",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status, err := changeChime(cmd.Context(), args[0], nil)
+		if err != nil {
+			return err
+		}
+		return printChime(status)
+	},
+}
+
+var doorbellsChimeEnableCmd = &cobra.Command{
+	Use:   "enable <doorbell ID or name>",
+	Short: "Enable chime. Type and duration are restored if they are not given",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		enabled := true
+		s := &chime.Settings{Enabled: &enabled}
+		if chimeType != "" {
+			s.Type = &chimeType
+		}
+		if cmd.Flags().Changed("duration") {
+			ms := int(chimeDuration / time.Millisecond)
+			s.DurationMs = &ms
+		}
+
+		status, err := changeChime(cmd.Context(), args[0], s)
+		if err != nil {
+			return err
+		}
+		return printChime(status)
+	},
+}
+
+var doorbellsChimeDisableCmd = &cobra.Command{
+	Use:   "disable <doorbell ID or name>",
+	Short: "Disable chime",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		enabled := false
+		status, err := changeChime(cmd.Context(), args[0], &chime.Settings{Enabled: &enabled})
+		if err != nil {
+			return err
+		}
+		return printChime(status)
+	},
+}
+
+var doorbellsChimeTestCmd = &cobra.Command{
+	Use:   "test <doorbell ID or name>",
+	Short: "Ring UniFi Chimes paired with doorbell",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if useAPI() {
+			c, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+			d, err := findAPIDoorbell(ctx, c, args[0])
+			if err != nil {
+				return err
+			}
+			res, err := c.TestChimeWithResponse(ctx, client.DoorbellID(d.Id))
+			if err != nil {
+				return xerrors.Errorf("failed to test chime: %w", err)
+			}
+			if err := client.CheckResponse(res.HTTPResponse, res.Body, http.StatusNoContent); err != nil {
+				return xerrors.Errorf("failed to test chime: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "rang chime of %s\n", d.Name)
+			return nil
+		}
+
+		r, err := authenticatedRegistry()
+		if err != nil {
+			return err
+		}
+		d, err := findDoorbell(ctx, r.UnifiClient(), args[0])
+		if err != nil {
+			return err
+		}
+		if err := r.Chime().Test(ctx, d.ID); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "rang chime of %s\n", d.Name)
+		return nil
+	},
+}
+
+// changeChime changes chime of the doorbell by s and returns its status. Nil s only gets status.
+func changeChime(ctx context.Context, key string, s *chime.Settings) (*chime.Status, error) {
+	if useAPI() {
+		return changeAPIChime(ctx, key, s)
+	}
+
+	r, err := authenticatedRegistry()
+	if err != nil {
+		return nil, err
+	}
+	d, err := findDoorbell(ctx, r.UnifiClient(), key)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return r.Chime().Status(ctx, d.ID)
+	}
+	return r.Chime().Set(ctx, d.ID, *s)
+}
+
+func changeAPIChime(ctx context.Context, key string, s *chime.Settings) (*chime.Status, error) {
+	c, err := newAPIClient()
+	if err != nil {
+		return nil, err
+	}
+	d, err := findAPIDoorbell(ctx, c, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if s == nil {
+		res, err := c.GetChimeWithResponse(ctx, client.DoorbellID(d.Id))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get chime: %w", err)
+		}
+		if err := client.CheckResponse(res.HTTPResponse, res.Body, http.StatusOK); err != nil {
+			return nil, xerrors.Errorf("failed to get chime: %w", err)
+		}
+		body = res.Body
+	} else {
+		settings := client.ChimeSettings{
+			Enabled:    s.Enabled,
+			DurationMs: s.DurationMs,
+		}
+		if s.Type != nil {
+			t := client.ChimeSettingsType(*s.Type)
+			settings.Type = &t
+		}
+		res, err := c.UpdateChimeWithResponse(ctx, client.DoorbellID(d.Id), client.UpdateChimeJSONRequestBody(settings))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to set chime: %w", err)
+		}
+		if err := client.CheckResponse(res.HTTPResponse, res.Body, http.StatusOK); err != nil {
+			return nil, xerrors.Errorf("failed to set chime: %w", err)
+		}
+		body = res.Body
+	}
+
+	var status chime.Status
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, xerrors.Errorf("failed to decode chime: %w", err)
+	}
+	return &status, nil
+}
+
+func printChime(s *chime.Status) error {
+	if output == outputJSON {
+		return printJSON(os.Stdout, s)
+	}
+
+	typ := s.Type
+	switch {
+	case typ == "":
+		typ = "-"
+	case typ == chime.TypeDigital:
+		typ = fmt.Sprintf("%s (%s)", typ, time.Duration(s.DurationMs)*time.Millisecond)
+	}
+	dnd := s.DND.String()
+	if s.DND.Active {
+		dnd += " (now)"
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DOORBELL\tENABLED\tTYPE\tDND")
+	fmt.Fprintf(tw, "%s\t%t\t%s\t%s\n", s.DoorbellName, s.Enabled, typ, dnd)
+	return tw.Flush()
+}
+
+func init() {
+	doorbellsChimeEnableCmd.Flags().StringVar(&chimeType, "type", "", "Type of chime. mechanical or digital")
+	doorbellsChimeEnableCmd.Flags().DurationVar(&chimeDuration, "duration", time.Second, "How long digital chime rings. 1s to 10s")
+
+	doorbellsChimeCmd.AddCommand(doorbellsChimeStatusCmd)
+	doorbellsChimeCmd.AddCommand(doorbellsChimeEnableCmd)
+	doorbellsChimeCmd.AddCommand(doorbellsChimeDisableCmd)
+	doorbellsChimeCmd.AddCommand(doorbellsChimeTestCmd)
+	doorbellsCmd.AddCommand(doorbellsChimeCmd)
+}
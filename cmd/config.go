@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sawadashota/unifi-doorbell-chime/driver/configuration"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v2"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect config file",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return loadConfig()
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:           "validate",
+	Short:         "Validate config file",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := configuration.NewViperProvider().Validate()
+		if err == nil {
+			fmt.Printf("%s is valid\n", configFilePath())
+			return nil
+		}
+
+		fmt.Fprintf(os.Stderr, "%s is invalid\n\n", configFilePath())
+		var errs configuration.ValidationErrors
+		if xerrors.As(err, &errs) {
+			for _, fe := range errs {
+				fmt.Fprintf(os.Stderr, "  - %s\n", fe)
+			}
+		} else {
+			fmt.Fprintf(os.Stderr, "  - %s\n", err)
+		}
+		return err
+	},
+}
+
+var redacted bool
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print config",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settings := viper.AllSettings()
+		if redacted {
+			settings = configuration.NewViperProvider().Redacted()
+		}
+
+		b, err := yaml.Marshal(settings)
+		if err != nil {
+			return xerrors.Errorf("failed to encode config: %w", err)
+		}
+		fmt.Print(string(b))
+		return nil
+	},
+}
+
+func init() {
+	configShowCmd.Flags().BoolVar(&redacted, "redacted", true, "Mask secrets such as password")
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configShowCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sawadashota/unifi-doorbell-chime/control"
+	"github.com/sawadashota/unifi-doorbell-chime/driver"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var (
+	controlSocket string
+	logsFollow    bool
+)
+
+// controlClient returns client of control socket given by --socket or config
+func controlClient() (*control.Client, error) {
+	if controlSocket != "" {
+		return control.NewClient(controlSocket), nil
+	}
+	if err := loadConfig(); err != nil {
+		return nil, err
+	}
+	return control.NewClient(driver.NewDefaultDriver().Configuration().ControlSocket()), nil
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show status of running daemon",
+	Args:  cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if output != outputTable && output != outputJSON {
+			return xerrors.Errorf("unknown output format %q. use table or json", output)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := controlClient()
+		if err != nil {
+			return err
+		}
+		s, err := c.Status(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if output == outputJSON {
+			return printJSON(os.Stdout, s)
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "SERVICE\tSTATE\tRESTARTS\tSINCE\tERROR")
+		for _, svc := range s.Services {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n",
+				svc.Name, svc.State, svc.Restarts, svc.Since.Format(time.RFC3339), svc.Error)
+		}
+		fmt.Fprintln(tw)
+
+		lastPoll := "-"
+		if !s.Listener.LastPoll.IsZero() {
+			lastPoll = s.Listener.LastPoll.Format(time.RFC3339)
+		}
+		notification := "active"
+		if s.Listener.Paused {
+			notification = "paused"
+		}
+		fmt.Fprintf(tw, "authenticated:\t%t\n", s.Listener.Authenticated)
+		fmt.Fprintf(tw, "notification:\t%s\n", notification)
+		fmt.Fprintf(tw, "last poll:\t%s\n", lastPoll)
+		if s.Listener.LastError != "" {
+			fmt.Fprintf(tw, "last error:\t%s\n", s.Listener.LastError)
+		}
+		fmt.Fprintln(tw)
+
+		fmt.Fprintln(tw, "DOORBELL\tID\tCONNECTED\tLAST RING")
+		for _, d := range s.Listener.Doorbells {
+			fmt.Fprintf(tw, "%s\t%s\t%t\t%s\n", d.Name, d.ID, d.IsConnected, formatUnixMilli(d.LastRing))
+		}
+		return tw.Flush()
+	},
+}
+
+var pauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Pause notifications of running daemon",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := controlClient()
+		if err != nil {
+			return err
+		}
+		if err := c.Pause(cmd.Context()); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "notification is paused")
+		return nil
+	},
+}
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume notifications of running daemon",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := controlClient()
+		if err != nil {
+			return err
+		}
+		if err := c.Resume(cmd.Context()); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "notification is resumed")
+		return nil
+	},
+}
+
+var reloadCmd = &cobra.Command{
+	Use:   "reload",
+	Short: "Reload config of running daemon",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := controlClient()
+		if err != nil {
+			return err
+		}
+		if err := c.Reload(cmd.Context()); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "config is reloaded")
+		return nil
+	},
+}
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Print logs of running daemon",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := controlClient()
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return c.Logs(ctx, os.Stdout, logsFollow)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{statusCmd, pauseCmd, resumeCmd, reloadCmd, logsCmd} {
+		c.Flags().StringVar(&controlSocket, "socket", "", "Control socket of running daemon. Default is control.socket of config")
+		rootCmd.AddCommand(c)
+	}
+	statusCmd.Flags().StringVar(&output, "output", outputTable, "Output format. table or json")
+	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Keep printing new logs")
+}
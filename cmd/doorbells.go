@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sawadashota/unifi-doorbell-chime/driver"
+	"github.com/sawadashota/unifi-doorbell-chime/event"
+	"github.com/sawadashota/unifi-doorbell-chime/listener"
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+var (
+	output          string
+	snapshotFile    string
+	snapshotPackage bool
+	messageDuration time.Duration
+)
+
+var doorbellsCmd = &cobra.Command{
+	Use:   "doorbells",
+	Short: "Inspect and control doorbells",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if output != outputTable && output != outputJSON {
+			return xerrors.Errorf("unknown output format %q. use table or json", output)
+		}
+		if useAPI() {
+			return nil
+		}
+		return loadConfig()
+	},
+}
+
+// authenticatedRegistry returns registry whose UniFi client is logged in already
+func authenticatedRegistry() (driver.Registry, error) {
+	d := driver.NewDefaultDriver()
+	if err := d.Configuration().Validate(); err != nil {
+		return nil, xerrors.Errorf("invalid config. fix %s\n%w", configFilePath(), err)
+	}
+
+	if err := d.Registry().UnifiClient().Authenticate(); err != nil {
+		return nil, xerrors.Errorf("failed to authenticate: %w", err)
+	}
+	return d.Registry(), nil
+}
+
+// authenticatedClient returns UniFi client logged in already
+func authenticatedClient() (*unifi.Client, error) {
+	r, err := authenticatedRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return r.UnifiClient(), nil
+}
+
+// findDoorbell finds doorbell by ID or name
+func findDoorbell(ctx context.Context, client *unifi.Client, key string) (*unifi.Doorbell, error) {
+	ds, err := client.GetDoorbells(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range ds {
+		if d.ID == key || d.Name == key {
+			return &d, nil
+		}
+	}
+	return nil, xerrors.Errorf("%s: %w", key, unifi.ErrDoorbellNotFound)
+}
+
+func formatUnixMilli(ms int64) string {
+	if ms <= 0 {
+		return "-"
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)).Format(time.RFC3339)
+}
+
+func printJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+var doorbellsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List doorbells",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if useAPI() {
+			return listAPIDoorbells(cmd.Context())
+		}
+
+		client, err := authenticatedClient()
+		if err != nil {
+			return err
+		}
+		ds, err := client.GetDoorbells(cmd.Context())
+		if err != nil {
+			return xerrors.Errorf("failed to list doorbells: %w", err)
+		}
+
+		if output == outputJSON {
+			type doorbell struct {
+				ID          string `json:"id"`
+				Name        string `json:"name"`
+				Model       string `json:"model"`
+				Mac         string `json:"mac"`
+				State       string `json:"state"`
+				IsConnected bool   `json:"is_connected"`
+				Firmware    string `json:"firmware"`
+				LastRing    string `json:"last_ring,omitempty"`
+			}
+			list := make([]doorbell, 0, len(ds))
+			for _, d := range ds {
+				v := doorbell{
+					ID:          d.ID,
+					Name:        d.Name,
+					Model:       d.Type,
+					Mac:         d.Mac,
+					State:       d.State,
+					IsConnected: d.IsConnected,
+					Firmware:    d.FirmwareVersion,
+				}
+				if d.LastRing > 0 {
+					v.LastRing = formatUnixMilli(int64(d.LastRing))
+				}
+				list = append(list, v)
+			}
+			return printJSON(os.Stdout, list)
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tID\tMODEL\tMAC\tSTATE\tFIRMWARE\tLAST RING")
+		for _, d := range ds {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				d.Name, d.ID, d.Type, d.Mac, d.State, d.FirmwareVersion, formatUnixMilli(int64(d.LastRing)))
+		}
+		return tw.Flush()
+	},
+}
+
+var doorbellsSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <doorbell ID or name>",
+	Short: "Save snapshot of doorbell",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if useAPI() {
+			return saveAPISnapshot(cmd.Context(), args[0], snapshotFile, snapshotPackage)
+		}
+
+		client, err := authenticatedClient()
+		if err != nil {
+			return err
+		}
+		d, err := findDoorbell(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+
+		path := snapshotFile
+		if path == "" {
+			path = snapshotFileName(d.ID, snapshotPackage)
+		}
+
+		var w io.Writer = os.Stdout
+		if path != "-" {
+			f, err := os.Create(path)
+			if err != nil {
+				return xerrors.Errorf("failed to create %s: %w", path, err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		get := client.GetSnapshot
+		if snapshotPackage {
+			get = client.GetPackageSnapshot
+		}
+		if err := get(cmd.Context(), w, d.ID); err != nil {
+			if path != "-" {
+				_ = os.Remove(path)
+			}
+			return xerrors.Errorf("failed to get snapshot: %w", err)
+		}
+		if path != "-" {
+			fmt.Fprintf(os.Stderr, "saved snapshot of %s to %s\n", d.Name, path)
+		}
+		return nil
+	},
+}
+
+// snapshotFileName returns default file name of snapshot
+func snapshotFileName(doorbellID string, pkg bool) string {
+	if pkg {
+		return doorbellID + "-package.jpg"
+	}
+	return doorbellID + ".jpg"
+}
+
+var doorbellsMessageCmd = &cobra.Command{
+	Use:   "message",
+	Short: "Control message on doorbell screen",
+}
+
+var doorbellsMessageSetCmd = &cobra.Command{
+	Use:   "set <doorbell ID or name> <message>",
+	Short: "Show message on doorbell screen",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if useAPI() {
+			return setAPIMessage(cmd.Context(), args[0], args[1])
+		}
+
+		client, err := authenticatedClient()
+		if err != nil {
+			return err
+		}
+		d, err := findDoorbell(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := client.SetMessage(cmd.Context(), d.ID, args[1], messageDuration); err != nil {
+			return xerrors.Errorf("failed to set message: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "set %q on %s for %s\n", args[1], d.Name, messageDuration)
+		return nil
+	},
+}
+
+var doorbellsWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Print doorbell events",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		if output == outputTable {
+			fmt.Fprintln(tw, "TIME\tTYPE\tDOORBELL\tMESSAGE")
+			_ = tw.Flush()
+		}
+		if useAPI() {
+			return watchAPIEvents(ctx, tw)
+		}
+
+		client, err := authenticatedClient()
+		if err != nil {
+			return err
+		}
+
+		state, err := client.GetDoorbells(ctx)
+		if err != nil {
+			return xerrors.Errorf("failed to get doorbells: %w", err)
+		}
+
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			ds, err := client.GetDoorbells(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				fmt.Fprintf(os.Stderr, "failed to poll: %s\n", err)
+				continue
+			}
+
+			for _, e := range listener.Detect(state, ds) {
+				if err := printEvent(tw, e); err != nil {
+					return err
+				}
+			}
+			state = ds
+		}
+	},
+}
+
+func printEvent(tw *tabwriter.Writer, e event.Event) error {
+	if output == outputJSON {
+		return json.NewEncoder(os.Stdout).Encode(&e)
+	}
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", e.Time.Format(time.RFC3339), e.Type, e.DoorbellName, e.Message)
+	return tw.Flush()
+}
+
+func init() {
+	doorbellsCmd.PersistentFlags().StringVar(&output, "output", outputTable, "Output format. table or json")
+	doorbellsCmd.PersistentFlags().StringVar(&apiServer, "server", os.Getenv("UDC_SERVER"), "API of running instance to use instead of UniFi Protect, e.g. http://doorbell.lan:8080/api/v1")
+	doorbellsCmd.PersistentFlags().StringVar(&apiToken, "token", os.Getenv("UDC_TOKEN"), "API token used with --server")
+	doorbellsSnapshotCmd.Flags().StringVarP(&snapshotFile, "file", "o", "", `Output file. "-" for stdout. Default is <doorbell ID>.jpg`)
+	doorbellsSnapshotCmd.Flags().BoolVar(&snapshotPackage, "package", false, "Save snapshot of package camera of doorbell like G4 Doorbell Pro")
+	doorbellsMessageSetCmd.Flags().DurationVar(&messageDuration, "duration", 60*time.Second, "How long to show the message")
+
+	doorbellsMessageCmd.AddCommand(doorbellsMessageSetCmd)
+	doorbellsCmd.AddCommand(doorbellsListCmd)
+	doorbellsCmd.AddCommand(doorbellsSnapshotCmd)
+	doorbellsCmd.AddCommand(doorbellsMessageCmd)
+	doorbellsCmd.AddCommand(doorbellsWatchCmd)
+	rootCmd.AddCommand(doorbellsCmd)
+}
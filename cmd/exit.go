@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"net"
+
+	"github.com/sawadashota/unifi-doorbell-chime/driver/configuration"
+	"github.com/sawadashota/unifi-doorbell-chime/listener"
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"golang.org/x/xerrors"
+)
+
+// exit codes follow sysexits.h so that service managers can tell failure class
+const (
+	ExitOK      = 0
+	ExitFailure = 1
+	// ExitNetwork is network or UniFi Protect is unavailable
+	ExitNetwork = 69
+	// ExitAuth is UniFi Protect rejected credentials
+	ExitAuth = 77
+	// ExitConfig is config file is missing or invalid
+	ExitConfig = 78
+)
+
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+func newConfigError(err error) error {
+	return &exitError{code: ExitConfig, err: err}
+}
+
+// ExitCode returns exit code of the failure class of err
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var ee *exitError
+	if xerrors.As(err, &ee) {
+		return ee.code
+	}
+	var ve configuration.ValidationErrors
+	if xerrors.As(err, &ve) {
+		return ExitConfig
+	}
+	if xerrors.Is(err, unifi.ErrUnauthorized) {
+		return ExitAuth
+	}
+	if xerrors.Is(err, listener.ErrUnreachable) {
+		return ExitNetwork
+	}
+	// port in use or not permitted is not a network failure
+	var oe *net.OpError
+	if xerrors.As(err, &oe) && oe.Op == "listen" {
+		return ExitFailure
+	}
+	var ne net.Error
+	if xerrors.As(err, &ne) {
+		return ExitNetwork
+	}
+	return ExitFailure
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/sawadashota/unifi-doorbell-chime/driver/configuration"
+	"github.com/sawadashota/unifi-doorbell-chime/listener"
+	"github.com/sawadashota/unifi-doorbell-chime/supervisor"
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"golang.org/x/xerrors"
+)
+
+func TestExitCode(t *testing.T) {
+	addrInUse := &net.OpError{Op: "listen", Net: "tcp", Err: os.NewSyscallError("bind", syscall.EADDRINUSE)}
+	refused := &url.Error{Op: "Post", URL: "https://192.168.1.1/api/auth", Err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}}
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "no error", err: nil, want: ExitOK},
+		{name: "unexpected", err: xerrors.New("unexpected"), want: ExitFailure},
+		{name: "config file", err: newConfigError(xerrors.New("config file is not found")), want: ExitConfig},
+		{name: "invalid config", err: xerrors.Errorf("invalid config: %w", configuration.ValidationErrors{{Key: "unifi.ip", Message: "is required"}}), want: ExitConfig},
+		{
+			name: "credentials rejected by listener",
+			err:  xerrors.Errorf("exit command: %w", xerrors.Errorf("listener is failed: %w", supervisor.Permanent(xerrors.Errorf("failed to authenticate: %w", unifi.ErrUnauthorized)))),
+			want: ExitAuth,
+		},
+		{
+			name: "UniFi Protect unreachable",
+			err:  xerrors.Errorf("exit command: %w", xerrors.Errorf("listener is failed: %w", supervisor.Permanent(xerrors.Errorf("gave up: %w", listener.ErrUnreachable)))),
+			want: ExitNetwork,
+		},
+		{name: "network error", err: xerrors.Errorf("failed to poll: %w", refused), want: ExitNetwork},
+		{name: "port in use", err: xerrors.Errorf("exit command: %w", xerrors.Errorf("exit web server: %w", addrInUse)), want: ExitFailure},
+	} {
+		if got := ExitCode(tc.err); got != tc.want {
+			t.Errorf("%s: got %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
@@ -1,41 +1,158 @@
 package cmd
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
+	"github.com/sawadashota/unifi-doorbell-chime/driver/configuration"
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"golang.org/x/xerrors"
 )
 
 const configTemplate = `---
 unifi:
-  ip: "192.168.1.1"
-  username: "username"
-  password: "password"
+  ip: {{ quote .IP }}
+  username: {{ quote .Username }}
+{{- if eq .PasswordStore "yaml" }}
+  password: {{ quote .Password }}
+{{- else if eq .PasswordStore "file" }}
+  password_file: {{ quote .PasswordFile }}
+{{- else if eq .PasswordStore "env" }}
+  # password is read from {{ .PasswordEnv }} environment variable
+{{- end }}
+{{- if not .SkipTLSVerify }}
+  skip_tls_verify: false
+{{- end }}
+{{- if eq .PasswordStore "keyring" }}
+
+secret:
+  keyring: true
+{{- end }}
+{{- if .Doorbells }}
+
+doorbells:
+  include:
+{{- range .Doorbells }}
+    - {{ quote . }}
+{{- end }}
+{{- end }}
 
 #boot_option:
 #  mac_address: 00:00:00:00:00:00
 
 message:
   templates:
-    - "I'm on my way"
-    - "I'm busy now"
+{{- range .Templates }}
+    - {{ quote . }}
+{{- end }}
 `
 
+const (
+	passwordStoreYAML    = "yaml"
+	passwordStoreFile    = "file"
+	passwordStoreEnv     = "env"
+	passwordStoreKeyring = "keyring"
+
+	unifiPasswordKey = "unifi.password"
+
+	discoveryTimeout = 3 * time.Second
+)
+
+var (
+	passwordStores   = []string{passwordStoreYAML, passwordStoreFile, passwordStoreEnv, passwordStoreKeyring}
+	defaultTemplates = []string{"I'm on my way", "I'm busy now"}
+)
+
+type initSettings struct {
+	IP            string
+	Username      string
+	Password      string
+	SkipTLSVerify bool
+	Doorbells     []string
+	Templates     []string
+	PasswordStore string
+	PasswordFile  string
+	PasswordEnv   string
+}
+
+var (
+	passwordStore  string
+	nonInteractive bool
+	force          bool
+	initIP         string
+	initUsername   string
+	initPassword   string
+)
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Generate config file and assets",
+	Long: `Generate config file.
+
+By default, it discovers UniFi Protect on LAN, verifies credentials and lets you pick doorbells and message templates.
+With --non-interactive, config file is generated from flags without any prompt and network access.`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		for _, s := range passwordStores {
+			if passwordStore == s {
+				return nil
+			}
+		}
+		return xerrors.Errorf("unknown password store %q", passwordStore)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := createConfigDir(); err != nil {
 			return xerrors.Errorf("failed to create config directory: %w", err)
 		}
-		if err := generateConfigFile(); err != nil {
+
+		settings := &initSettings{
+			IP:            initIP,
+			Username:      initUsername,
+			Password:      initPassword,
+			SkipTLSVerify: true,
+			Templates:     defaultTemplates,
+			PasswordStore: passwordStore,
+			PasswordFile:  passwordFilePath(),
+			PasswordEnv:   configuration.EnvKey(unifiPasswordKey),
+		}
+		if settings.Password == "" {
+			settings.Password = os.Getenv(settings.PasswordEnv)
+		}
+
+		if nonInteractive {
+			if err := completeNonInteractive(settings); err != nil {
+				return err
+			}
+		} else {
+			if err := runWizard(cmd.Context(), newPrompter(), settings, cmd.Flags().Changed("password-store")); err != nil {
+				return err
+			}
+		}
+
+		if err := storePassword(settings); err != nil {
+			return xerrors.Errorf("failed to store password: %w", err)
+		}
+		if err := generateConfigFile(settings); err != nil {
 			return xerrors.Errorf("failed to generate config file: %w", err)
 		}
 
-		fmt.Printf("created %s successfully\n\n", configDir())
-		fmt.Printf("$ vi %s\n\n", configFilePath())
+		fmt.Printf("created %s successfully\n\n", configFilePath())
+		if nonInteractive {
+			fmt.Printf("$ vi %s\n\n", configFilePath())
+		}
+		if settings.PasswordStore == passwordStoreEnv {
+			fmt.Printf("$ export %s=<password>\n\n", settings.PasswordEnv)
+		}
 		fmt.Printf("then exec\n\n")
 		fmt.Println("$ unifi-doorbell-chime start")
 		return nil
@@ -43,26 +160,223 @@ var initCmd = &cobra.Command{
 }
 
 func createConfigDir() error {
-	if _, err := os.Stat(configDir()); err == nil {
-		return xerrors.Errorf("%s already exist", configDir())
+	if _, err := os.Stat(configFilePath()); err == nil && !force {
+		return xerrors.Errorf("%s already exist. use --force to overwrite", configFilePath())
+	}
+	if err := os.MkdirAll(filepath.Dir(configFilePath()), 0775); err != nil {
+		return xerrors.Errorf("failed to create directory at %s. %s", filepath.Dir(configFilePath()), err)
+	}
+	return nil
+}
+
+func passwordFilePath() string {
+	return filepath.Join(filepath.Dir(configFilePath()), "unifi_password")
+}
+
+func completeNonInteractive(s *initSettings) error {
+	if s.IP == "" {
+		s.IP = "192.168.1.1"
+	}
+	if s.Username == "" {
+		s.Username = "username"
+	}
+	switch s.PasswordStore {
+	case passwordStoreYAML:
+		if s.Password == "" {
+			s.Password = "password"
+		}
+	case passwordStoreFile, passwordStoreKeyring:
+		if s.Password == "" {
+			return xerrors.Errorf("--password or %s is required to store password in %s", s.PasswordEnv, s.PasswordStore)
+		}
+	}
+	return nil
+}
+
+func runWizard(ctx context.Context, p *prompter, s *initSettings, passwordStoreGiven bool) error {
+	if s.IP == "" {
+		ip, err := chooseController(ctx, p)
+		if err != nil {
+			return err
+		}
+		s.IP = ip
+	}
+
+	var doorbells unifi.Doorbells
+	for {
+		var err error
+		if s.Username, err = p.ask("UniFi Protect username", s.Username); err != nil {
+			return err
+		}
+		if s.Password == "" {
+			if s.Password, err = p.askPassword("UniFi Protect password"); err != nil {
+				return err
+			}
+		}
+
+		fmt.Fprintf(p.out, "verifying credentials at %s...\n", s.IP)
+		doorbells, err = verifyCredentials(ctx, s)
+		if err == nil {
+			break
+		}
+
+		fmt.Fprintf(p.out, "failed to verify: %s\n", err)
+		retry, err := p.confirm("Retry?", true)
+		if err != nil {
+			return err
+		}
+		if !retry {
+			return xerrors.New("credentials are not verified")
+		}
+		s.Password = ""
+		if s.IP, err = p.ask("UniFi Protect address", s.IP); err != nil {
+			return err
+		}
+	}
+
+	if len(doorbells) == 0 {
+		fmt.Fprintln(p.out, "no doorbell is found. every doorbell added later will be notified")
+	} else {
+		options := make([]string, 0, len(doorbells))
+		for _, d := range doorbells {
+			options = append(options, fmt.Sprintf("%s (%s)", d.Name, d.ID))
+		}
+		picked, err := p.chooseMany("Doorbells to notify", options)
+		if err != nil {
+			return err
+		}
+		if len(picked) < len(doorbells) {
+			for _, i := range picked {
+				s.Doorbells = append(s.Doorbells, doorbells[i].ID)
+			}
+		}
 	}
-	if err := os.MkdirAll(configDir(), 0775); err != nil {
-		return xerrors.Errorf("failed to create directory at %s. %s", configDir(), err)
+
+	fmt.Fprintf(p.out, "default message templates: %s\n", strings.Join(defaultTemplates, ", "))
+	useDefault, err := p.confirm("Use default message templates?", true)
+	if err != nil {
+		return err
+	}
+	if !useDefault {
+		templates, err := p.askList("Message templates")
+		if err != nil {
+			return err
+		}
+		if len(templates) > 0 {
+			s.Templates = templates
+		}
+	}
+
+	if !passwordStoreGiven {
+		i, err := p.choose("Where to store password", []string{
+			"config file",
+			"separated file readable only by you",
+			fmt.Sprintf("%s environment variable", s.PasswordEnv),
+			"OS keyring",
+		}, 0)
+		if err != nil {
+			return err
+		}
+		s.PasswordStore = passwordStores[i]
 	}
 	return nil
 }
 
-func generateConfigFile() error {
-	file, err := os.OpenFile(configFilePath(), os.O_WRONLY|os.O_CREATE, 0666)
+func chooseController(ctx context.Context, p *prompter) (string, error) {
+	fmt.Fprintln(p.out, "searching UniFi devices on LAN...")
+	devices, err := unifi.Discover(ctx, discoveryTimeout)
+	if err != nil {
+		fmt.Fprintf(p.out, "failed to discover: %s\n", err)
+	}
+
+	options := make([]string, 0, len(devices)+1)
+	for _, d := range devices {
+		options = append(options, fmt.Sprintf("%s %s %s", d.IP, d.Model, d.Hostname))
+	}
+	options = append(options, "enter address manually")
+
+	i, err := p.choose("UniFi Protect controller", options, 0)
+	if err != nil {
+		return "", err
+	}
+	if i < len(devices) {
+		return devices[i].IP.String(), nil
+	}
+	return p.ask("UniFi Protect address", "")
+}
+
+type initConfiguration struct {
+	s *initSettings
+}
+
+func (c *initConfiguration) UnifiIp() string                { return c.s.IP }
+func (c *initConfiguration) UnifiUsername() string          { return c.s.Username }
+func (c *initConfiguration) UnifiPassword() (string, error) { return c.s.Password, nil }
+func (c *initConfiguration) DoorbellsInclude() []string     { return nil }
+func (c *initConfiguration) DoorbellsExclude() []string     { return nil }
+func (c *initConfiguration) AppLogger(string) logrus.FieldLogger {
+	l := logrus.New()
+	l.SetLevel(logrus.FatalLevel)
+	return l
+}
+
+func verifyCredentials(ctx context.Context, s *initSettings) (unifi.Doorbells, error) {
+	httpclient := http.DefaultClient
+	if s.SkipTLSVerify {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402
+		httpclient = &http.Client{Transport: transport}
+	}
+
+	c := &initConfiguration{s: s}
+	client := unifi.NewClient(c, c, httpclient)
+	if err := client.Authenticate(); err != nil {
+		return nil, xerrors.Errorf("failed to authenticate: %w", err)
+	}
+	doorbells, err := client.GetDoorbells(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get doorbells: %w", err)
+	}
+	return doorbells, nil
+}
+
+func storePassword(s *initSettings) error {
+	switch s.PasswordStore {
+	case passwordStoreFile:
+		return ioutil.WriteFile(s.PasswordFile, []byte(s.Password+"\n"), 0600)
+	case passwordStoreKeyring:
+		return configuration.StoreSecretInKeyring(unifiPasswordKey, s.Password)
+	}
+	return nil
+}
+
+func generateConfigFile(s *initSettings) error {
+	file, err := os.OpenFile(configFilePath(), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return xerrors.Errorf("failed to create config file at %s. %s", configFilePath(), err)
 	}
 	defer file.Close()
 
-	_, err = fmt.Fprint(file, configTemplate)
-	return xerrors.Errorf("failed to write config file: %w", err)
+	tmpl := template.Must(template.New("config").Funcs(template.FuncMap{
+		"quote": strconv.Quote,
+	}).Parse(configTemplate))
+	if err := tmpl.Execute(file, s); err != nil {
+		return xerrors.Errorf("failed to write config file: %w", err)
+	}
+	return nil
 }
 
 func init() {
+	initCmd.Flags().StringVar(
+		&passwordStore,
+		"password-store",
+		passwordStoreYAML,
+		"Where to store UniFi password. yaml, file, env or keyring",
+	)
+	initCmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "Generate config file from flags without prompt")
+	initCmd.Flags().BoolVar(&force, "force", false, "Overwrite existing config file")
+	initCmd.Flags().StringVar(&initIP, "ip", "", "IP address or host name of UniFi Protect")
+	initCmd.Flags().StringVar(&initUsername, "username", "", "UniFi Protect username")
+	initCmd.Flags().StringVar(&initPassword, "password", "", "UniFi Protect password. "+configuration.EnvKey(unifiPasswordKey)+" is used if omitted")
 	rootCmd.AddCommand(initCmd)
 }
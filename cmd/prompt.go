@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+	"golang.org/x/xerrors"
+)
+
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newPrompter() *prompter {
+	return &prompter{
+		in:  bufio.NewReader(os.Stdin),
+		out: os.Stdout,
+	}
+}
+
+func (p *prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", xerrors.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// ask returns answer or defaultValue if answer is empty
+func (p *prompter) ask(question, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, defaultValue)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	answer, err := p.readLine()
+	if err != nil {
+		return "", err
+	}
+	if answer = strings.TrimSpace(answer); answer == "" {
+		return defaultValue, nil
+	}
+	return answer, nil
+}
+
+func (p *prompter) askPassword(question string) (string, error) {
+	fmt.Fprintf(p.out, "%s: ", question)
+	defer fmt.Fprintln(p.out)
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		b, err := term.ReadPassword(fd)
+		if err != nil {
+			return "", xerrors.Errorf("failed to read password: %w", err)
+		}
+		return string(b), nil
+	}
+	return p.readLine()
+}
+
+func (p *prompter) confirm(question string, defaultValue bool) (bool, error) {
+	hint := "y/N"
+	if defaultValue {
+		hint = "Y/n"
+	}
+	answer, err := p.ask(fmt.Sprintf("%s (%s)", question, hint), "")
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "":
+		return defaultValue, nil
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// choose lets user pick one of options by number and returns its index
+func (p *prompter) choose(question string, options []string, defaultIndex int) (int, error) {
+	for i, o := range options {
+		fmt.Fprintf(p.out, "  %d) %s\n", i+1, o)
+	}
+	for {
+		answer, err := p.ask(question, strconv.Itoa(defaultIndex+1))
+		if err != nil {
+			return 0, err
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		fmt.Fprintf(p.out, "enter number between 1 and %d\n", len(options))
+	}
+}
+
+// chooseMany lets user pick options by comma separated numbers. Empty answer picks all.
+// Options picked more than once are returned once in the order of first pick.
+func (p *prompter) chooseMany(question string, options []string) ([]int, error) {
+	for i, o := range options {
+		fmt.Fprintf(p.out, "  %d) %s\n", i+1, o)
+	}
+	for {
+		answer, err := p.ask(question+" (comma separated, empty for all)", "")
+		if err != nil {
+			return nil, err
+		}
+
+		var picked []int
+		if answer == "" {
+			for i := range options {
+				picked = append(picked, i)
+			}
+			return picked, nil
+		}
+
+		valid := true
+		seen := make(map[int]bool)
+		for _, v := range strings.Split(answer, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil || n < 1 || n > len(options) {
+				valid = false
+				break
+			}
+			if !seen[n] {
+				seen[n] = true
+				picked = append(picked, n-1)
+			}
+		}
+		if valid {
+			return picked, nil
+		}
+		fmt.Fprintf(p.out, "enter numbers between 1 and %d\n", len(options))
+	}
+}
+
+// askList reads lines until empty line
+func (p *prompter) askList(question string) ([]string, error) {
+	fmt.Fprintf(p.out, "%s (one per line, empty line to finish)\n", question)
+	var list []string
+	for {
+		fmt.Fprint(p.out, "> ")
+		line, err := p.readLine()
+		if err != nil {
+			return list, nil
+		}
+		if line = strings.TrimSpace(line); line == "" {
+			return list, nil
+		}
+		list = append(list, line)
+	}
+}
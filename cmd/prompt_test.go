@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"bufio"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPrompterChooseMany(t *testing.T) {
+	options := []string{"Front Door", "Back Door", "Porch"}
+	for _, tc := range []struct {
+		answer string
+		want   []int
+	}{
+		{answer: "\n", want: []int{0, 1, 2}},
+		{answer: "2\n", want: []int{1}},
+		{answer: "3, 1\n", want: []int{2, 0}},
+		{answer: "1,1\n", want: []int{0}},
+		{answer: "2,1,2\n", want: []int{1, 0}},
+		{answer: "4\n1\n", want: []int{0}},
+	} {
+		p := &prompter{
+			in:  bufio.NewReader(strings.NewReader(tc.answer)),
+			out: ioutil.Discard,
+		}
+		got, err := p.chooseMany("Doorbells to notify", options)
+		if err != nil {
+			t.Fatalf("%q: %s", tc.answer, err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: got %v, want %v", tc.answer, got, tc.want)
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var (
@@ -13,7 +15,7 @@ var (
 
 var rootCmd = &cobra.Command{
 	Use:     "unifi-doorbell-chime",
-	Short:   "Notify UniFi UVC G4 Doorbell ringing",
+	Short:   "Notify UniFi Protect doorbell ringing",
 	Version: Version,
 }
 
@@ -31,3 +33,24 @@ func configFilePath() string {
 	}
 	return configFile
 }
+
+func loadConfig() error {
+	viper.SetConfigFile(configFilePath())
+
+	if err := viper.ReadInConfig(); err != nil {
+		return newConfigError(fmt.Errorf(`config file not found because "%s"`, err))
+	}
+	return nil
+}
+
+var configFile string
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(
+		&configFile,
+		"config",
+		"c",
+		"",
+		"Config file. Default is $HOME/.unifi-doorbell-chime/config.yaml",
+	)
+}
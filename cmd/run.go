@@ -2,60 +2,76 @@ package cmd
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/sawadashota/unifi-doorbell-chime/driver"
-	"github.com/sawadashota/unifi-doorbell-chime/x/wifimac"
+	"github.com/sawadashota/unifi-doorbell-chime/listener"
+	"github.com/sawadashota/unifi-doorbell-chime/supervisor"
+	"github.com/sawadashota/unifi-doorbell-chime/x/netenv"
+	"github.com/sawadashota/unifi-doorbell-chime/x/sdnotify"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"golang.org/x/sync/errgroup"
 	"golang.org/x/xerrors"
 )
 
 var runCmd = &cobra.Command{
-	Use:   "start",
-	Short: "start listen to doorbell ringing",
+	Use:          "start",
+	Short:        "start listen to doorbell ringing",
+	SilenceUsage: true,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
-		viper.SetConfigFile(configFilePath())
-
-		if err := viper.ReadInConfig(); err != nil {
-			return fmt.Errorf(`config file not found because "%s"`, err)
-		}
-		return nil
+		return loadConfig()
 	},
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		d := driver.NewDefaultDriver()
 		i := newInstance(d)
 
-		var eg errgroup.Group
-		defer func() {
-			if err := eg.Wait(); err != nil {
-				d.Registry().Logger().Error(err)
-			}
-		}()
+		if err := d.Configuration().Validate(); err != nil {
+			return newConfigError(xerrors.Errorf("invalid config. fix %s\n%w", configFilePath(), err))
+		}
+
+		d.Configuration().WatchConfig(func(err error) {
+			d.Registry().Logger().Errorf("keep current config because failed to reload: %s", err)
+		})
 
 		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
 		defer stop()
 
+		i.notifyReady(ctx)
 		errCh := make(chan error, 1)
-		eg.Go(func() error {
-			if d.Configuration().BootOptionMacAddress() != "" {
-				return i.bootWithMacAddressObservation(ctx)
+		go func() {
+			if len(d.Configuration().Networks()) > 0 {
+				errCh <- i.bootOnNetworks(ctx)
+				return
 			}
-			return i.boot(ctx)
-		})
+			errCh <- i.boot(ctx)
+		}()
 
 		select {
-		case <-ctx.Done():
+		case err := <-errCh:
+			if err != nil {
+				d.Registry().Logger().Debugf("%+v", err)
+				return xerrors.Errorf("exit command: %w", err)
+			}
 			return nil
+		case <-ctx.Done():
+		}
+
+		i.notify(sdnotify.Stopping)
+		// servers wait for in-flight requests by shutdown timeout themselves, so services get more time
+		timeout := time.Duration(d.Configuration().ShutdownTimeoutSec())*time.Second + shutdownMargin
+		i.logger.Infof("shutting down. wait for services at most %s", timeout)
+		select {
 		case err := <-errCh:
-			d.Registry().Logger().Debugf("%+v", err)
-			return xerrors.Errorf("exit command: %w", err)
+			if err != nil {
+				i.logger.Error(err)
+			}
+			return nil
+		case <-time.After(timeout):
+			return xerrors.Errorf("services did not stop within %s", timeout)
 		}
 	},
 }
@@ -63,6 +79,9 @@ var runCmd = &cobra.Command{
 type instance struct {
 	d      driver.Driver
 	logger logrus.FieldLogger
+
+	// waitingNetwork is 1 while services are stopped until host joins acceptable network
+	waitingNetwork int32
 }
 
 func newInstance(d driver.Driver) *instance {
@@ -72,112 +91,205 @@ func newInstance(d driver.Driver) *instance {
 	}
 }
 
-func (i *instance) bootWithMacAddressObservation(ctx context.Context) error {
-	desired := i.d.Configuration().BootOptionMacAddress()
+const (
+	// shutdownMargin is time for services to stop after servers finish shutdown
+	shutdownMargin = 5 * time.Second
+	// readyCheckInterval is interval to check whether services are started
+	readyCheckInterval = 500 * time.Millisecond
+	// listenerStallTimeout is how long listener may not poll UniFi Protect before watchdog regards it as hung.
+	// It is longer than max interval of reconnecting.
+	listenerStallTimeout = 2 * time.Minute
+)
+
+// notifyReady tells systemd that startup is finished once services are running
+// and keeps watchdog alive while listener keeps polling until ctx is done
+func (i *instance) notifyReady(ctx context.Context) {
+	// services are resolved before boot because registry creates them lazily
+	h := &health{
+		services: len(i.d.Registry().Services()),
+		sv:       i.d.Registry().Supervisor(),
+		ls:       i.d.Registry().Listener(),
+		waiting:  &i.waitingNetwork,
+	}
 
-	i.logger.Infof("boot only when mac address is %s", desired)
-	for {
-		if i.isDesiredMacAddress() {
-			if err := i.checkWorthToContinue(i.boot(ctx)); err != nil {
-				if xerrors.Is(err, complete) {
-					return nil
+	go func() {
+		ticker := time.NewTicker(readyCheckInterval)
+		defer ticker.Stop()
+		for !h.ready() {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+		i.notify(sdnotify.Ready)
+		i.logger.Debug("notified systemd of readiness")
+
+		interval, ok := sdnotify.WatchdogInterval()
+		if !ok {
+			return
+		}
+		watchdog := time.NewTicker(interval / 2)
+		defer watchdog.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-watchdog.C:
+				// systemd restarts the process if watchdog is not kept alive
+				if err := h.alive(); err != nil {
+					i.logger.Errorf("watchdog is not kept alive: %s", err)
+					continue
 				}
-				return err
+				i.notify(sdnotify.Watchdog)
 			}
 		}
-		time.Sleep(1 * time.Minute)
-	}
+	}()
 }
 
-func (i *instance) isDesiredMacAddress() bool {
-	desired := i.d.Configuration().BootOptionMacAddress()
+// health tells readiness and liveness of services to systemd
+type health struct {
+	services int
+	sv       *supervisor.Supervisor
+	ls       *listener.Listener
+	waiting  *int32
+}
 
-	ma, err := wifimac.GetMacAddress()
-	if err != nil {
-		i.logger.Infof("it seem no network connected. waiting until mac address to be %s", desired)
-		return false
+// ready reports whether every service is running and listener has polled UniFi Protect
+func (h *health) ready() bool {
+	if atomic.LoadInt32(h.waiting) == 1 {
+		return true
 	}
-	if ma.String() != desired {
-		i.logger.Infof("current mac address is %s. waiting to be %s", ma.String(), desired)
+
+	statuses := h.sv.Statuses()
+	if len(statuses) < h.services {
 		return false
 	}
-	return true
-}
-
-var (
-	complete = xerrors.New("complete successfully")
-)
+	for _, s := range statuses {
+		if s.State != supervisor.StateRunning {
+			return false
+		}
+	}
 
-func (i *instance) checkWorthToContinue(err error) error {
-	desired := i.d.Configuration().BootOptionMacAddress()
+	s := h.ls.Status()
+	return s.Authenticated && !s.LastPoll.IsZero()
+}
 
-	if err == nil {
-		return complete
-	}
-	ma2, err2 := wifimac.GetMacAddress()
-	if err2 != nil {
-		i.logger.Infof(
-			"listener stopped because no network connected. waiting until mac address to be %s",
-			desired,
-		)
+// alive returns error if listener doesn't poll UniFi Protect for a while
+func (h *health) alive() error {
+	if atomic.LoadInt32(h.waiting) == 1 {
 		return nil
 	}
-
-	if ma2.String() == desired {
-		return xerrors.Errorf("unexpected error occurred: %w", err)
+	last := h.ls.Status().LastPoll
+	if time.Since(last) > listenerStallTimeout {
+		return xerrors.Errorf("listener has not polled since %s", last.Format(time.RFC3339))
 	}
-
-	i.logger.Infof("listener stopped because current mac address is %s. waiting to be %s",
-		ma2.String(),
-		desired,
-	)
 	return nil
 }
 
-func (i *instance) boot(ctx context.Context) error {
-	var eg errgroup.Group
+func (i *instance) notify(state string) {
+	if _, err := sdnotify.Notify(state); err != nil {
+		i.logger.Warn(err)
+	}
+}
+
+// networkSettleDuration is how long to wait after network change for address and route to be settled
+const networkSettleDuration = 2 * time.Second
+
+// bootOnNetworks runs services only while the host is on any of acceptable networks.
+// Network is checked on change notification if available and periodically.
+func (i *instance) bootOnNetworks(ctx context.Context) error {
+	changes := netenv.Changes(ctx)
+	ticker := time.NewTicker(time.Duration(i.d.Configuration().NetworkCheckIntervalSec()) * time.Second)
+	defer ticker.Stop()
+
+	var (
+		cancel   context.CancelFunc = func() {}
+		done     chan error
+		previous string
+	)
 	defer func() {
-		if err := eg.Wait(); err != nil {
-			i.logger.Error(err)
-		}
+		cancel()
 	}()
 
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	errCh := make(chan error, len(i.d.Registry().Services()))
-	for _, svc := range i.d.Registry().Services() {
-		s := svc
-		eg.Go(func() error {
-			if err := s.Start(ctx); err != nil {
-				if xerrors.Is(err, context.Canceled) {
-					return nil
-				}
-				errCh <- err
-				return xerrors.Errorf("an error occurred at %T : %w", s, err)
+	for {
+		state := i.detectNetwork(ctx)
+		n, ok := netenv.MatchAny(i.d.Configuration().Networks(), state)
+		switch {
+		case ok && done == nil:
+			i.logger.Infof("start on network %s", n)
+			cancel, done = i.bootBackground(ctx)
+
+		case !ok && done != nil:
+			i.logger.Infof("stop because host left acceptable networks. %s", state)
+			cancel()
+			if err := <-done; err != nil {
+				i.logger.Debugf("%+v", err)
+			}
+			done = nil
+
+		case !ok && state.String() != previous:
+			i.logger.Infof("waiting for acceptable network. %s", state)
+		}
+		previous = state.String()
+		if done == nil {
+			atomic.StoreInt32(&i.waitingNetwork, 1)
+		} else {
+			atomic.StoreInt32(&i.waitingNetwork, 0)
+		}
+
+		select {
+		case <-ctx.Done():
+			if done != nil {
+				return <-done
 			}
 			return nil
-		})
-	}
 
-	select {
-	case <-ctx.Done():
-		return nil
-	case err := <-errCh:
-		cancel()
-		return xerrors.Errorf("all process canceled: %w", err)
+		case err := <-done:
+			done = nil
+			cancel()
+			if err == nil {
+				return nil
+			}
+			// failure by leaving network is expected
+			if _, ok := netenv.MatchAny(i.d.Configuration().Networks(), i.detectNetwork(ctx)); ok {
+				return xerrors.Errorf("unexpected error occurred: %w", err)
+			}
+			i.logger.Infof("stopped because network is changed: %s", err)
+
+		case _, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(networkSettleDuration):
+			}
+
+		case <-ticker.C:
+		}
 	}
 }
 
-var configFile string
+// bootBackground boots services in background. Result of boot is sent to the channel.
+func (i *instance) bootBackground(ctx context.Context) (context.CancelFunc, chan error) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- i.boot(ctx)
+	}()
+	return cancel, done
+}
+
+func (i *instance) detectNetwork(ctx context.Context) netenv.State {
+	return netenv.Detect(ctx, i.d.Registry().UnifiClient().Addr())
+}
+
+func (i *instance) boot(ctx context.Context) error {
+	return i.d.Registry().Supervisor().Run(ctx, i.d.Registry().Services()...)
+}
 
 func init() {
-	runCmd.PersistentFlags().StringVarP(
-		&configFile,
-		"config",
-		"c",
-		"",
-		"Config file. Default is $HOME/.unifi-doorbell-chime/config.yaml",
-	)
 	rootCmd.AddCommand(runCmd)
 }
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sawadashota/unifi-doorbell-chime/driver"
+	"github.com/sawadashota/unifi-doorbell-chime/listener"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var testRingServeFor time.Duration
+
+var testRingCmd = &cobra.Command{
+	Use:   "test-ring [doorbell ID or name]",
+	Short: "Simulate ringing to check snapshot, event delivery and notifiers",
+	Long: `Simulate ringing to check snapshot, event delivery and notifiers.
+The first doorbell is used if doorbell is omitted.
+Web and API servers keep serving ringing page for --serve-for after ringing.`,
+	Args: cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if output != outputTable && output != outputJSON {
+			return xerrors.Errorf("unknown output format %q. use table or json", output)
+		}
+		return loadConfig()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		d := driver.NewDefaultDriver()
+		if err := d.Configuration().Validate(); err != nil {
+			return xerrors.Errorf("invalid config. fix %s\n%w", configFilePath(), err)
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		client := d.Registry().UnifiClient()
+		if err := client.Authenticate(); err != nil {
+			return xerrors.Errorf("failed to authenticate: %w", err)
+		}
+
+		var doorbellID string
+		if len(args) == 0 {
+			ds, err := client.GetDoorbells(ctx)
+			if err != nil {
+				return xerrors.Errorf("failed to get doorbells: %w", err)
+			}
+			if len(ds) == 0 {
+				return xerrors.New("no doorbell found")
+			}
+			doorbellID = ds[0].ID
+		} else {
+			db, err := findDoorbell(ctx, client, args[0])
+			if err != nil {
+				return err
+			}
+			doorbellID = db.ID
+		}
+
+		// serve ringing page opened by notifiers
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		done := make(chan error, 1)
+		go func() {
+			done <- d.Registry().Supervisor().Run(ctx, d.Registry().WebServices()...)
+		}()
+		if err := waitForPort(ctx, d.Configuration().WebPort()); err != nil {
+			return err
+		}
+
+		report, err := d.Registry().Listener().SimulateRing(ctx, doorbellID)
+		if err != nil {
+			return err
+		}
+		if err := printRingReport(report); err != nil {
+			return err
+		}
+
+		if testRingServeFor > 0 {
+			fmt.Fprintf(os.Stderr, "serving ringing page for %s. press Ctrl-C to exit\n", testRingServeFor)
+			select {
+			case <-ctx.Done():
+			case <-time.After(testRingServeFor):
+			case err := <-done:
+				if err != nil {
+					return err
+				}
+			}
+		}
+		cancel()
+
+		if !report.OK() {
+			return xerrors.New("some steps of ringing failed")
+		}
+		return nil
+	},
+}
+
+func waitForPort(ctx context.Context, port int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			return conn.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return xerrors.Errorf("server of %s is not ready: %w", addr, err)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func printRingReport(report *listener.RingReport) error {
+	if output == outputJSON {
+		return printJSON(os.Stdout, report)
+	}
+
+	fmt.Fprintf(os.Stdout, "simulated ring of %s (%s)\n", report.Event.DoorbellName, report.Event.DoorbellID)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "STEP\tRESULT\tERROR")
+	for _, s := range report.Steps {
+		result := "ok"
+		switch {
+		case !s.OK:
+			result = "failed"
+		case s.Skipped:
+			result = "skipped"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", s.Name, result, s.Error)
+	}
+	return tw.Flush()
+}
+
+func init() {
+	testRingCmd.Flags().StringVar(&output, "output", outputTable, "Output format. table or json")
+	testRingCmd.Flags().DurationVar(&testRingServeFor, "serve-for", time.Minute, "How long to keep serving ringing page after ringing. 0 to exit immediately")
+	rootCmd.AddCommand(testRingCmd)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/sawadashota/unifi-doorbell-chime/driver"
+	"github.com/sawadashota/unifi-doorbell-chime/x/tlsconfig"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var tlsCAOutput string
+
+var tlsCmd = &cobra.Command{
+	Use:   "tls",
+	Short: "Manage TLS certificate of web servers",
+}
+
+var tlsExportCACmd = &cobra.Command{
+	Use:   "export-ca",
+	Short: "Print CA certificate issuing self-signed certificate to install on phones and tablets",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := loadConfig(); err != nil {
+			return err
+		}
+		d := driver.NewDefaultDriver()
+		c := d.Configuration()
+
+		// create CA in advance so that devices trust it before the first start
+		hosts := append(tlsconfig.LocalHosts(), c.TLSSelfSignedHosts()...)
+		if _, err := tlsconfig.SelfSigned(c.TLSSelfSignedDir(), hosts); err != nil {
+			return err
+		}
+		b, err := ioutil.ReadFile(d.Registry().TLS().CAPath())
+		if err != nil {
+			return xerrors.Errorf("failed to read CA: %w", err)
+		}
+
+		if tlsCAOutput == "" {
+			_, err := os.Stdout.Write(b)
+			return err
+		}
+		if err := ioutil.WriteFile(tlsCAOutput, b, 0644); err != nil {
+			return xerrors.Errorf("failed to write CA: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "CA certificate is written to %s\n", tlsCAOutput)
+		return nil
+	},
+}
+
+func init() {
+	tlsExportCACmd.Flags().StringVarP(&tlsCAOutput, "output", "o", "", "File to write CA certificate. default is stdout")
+
+	tlsCmd.AddCommand(tlsExportCACmd)
+	rootCmd.AddCommand(tlsCmd)
+}
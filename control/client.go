@@ -0,0 +1,111 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+
+	"golang.org/x/xerrors"
+)
+
+// ErrNotRunning is returned when no daemon listens control socket
+var ErrNotRunning = xerrors.New("daemon is not running")
+
+// Client calls control API of running daemon via Unix domain socket
+type Client struct {
+	path       string
+	httpclient *http.Client
+}
+
+func NewClient(path string) *Client {
+	return &Client{
+		path: path,
+		httpclient: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", path)
+				},
+			},
+		},
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, "http://unix"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.httpclient.Do(req)
+	if err != nil {
+		return nil, xerrors.Errorf("%s: %w", c.path, ErrNotRunning)
+	}
+	if res.StatusCode >= 300 {
+		defer res.Body.Close()
+		var e errorResponse
+		if err := json.NewDecoder(res.Body).Decode(&e); err == nil && e.Error != "" {
+			return nil, xerrors.New(e.Error)
+		}
+		return nil, xerrors.Errorf("unexpected status %s", res.Status)
+	}
+	return res, nil
+}
+
+func (c *Client) Status(ctx context.Context) (*Status, error) {
+	res, err := c.do(ctx, http.MethodGet, "/status")
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get status: %w", err)
+	}
+	defer res.Body.Close()
+
+	var s Status
+	if err := json.NewDecoder(res.Body).Decode(&s); err != nil {
+		return nil, xerrors.Errorf("failed to decode status: %w", err)
+	}
+	return &s, nil
+}
+
+func (c *Client) Pause(ctx context.Context) error {
+	res, err := c.do(ctx, http.MethodPost, "/pause")
+	if err != nil {
+		return xerrors.Errorf("failed to pause: %w", err)
+	}
+	return res.Body.Close()
+}
+
+func (c *Client) Resume(ctx context.Context) error {
+	res, err := c.do(ctx, http.MethodPost, "/resume")
+	if err != nil {
+		return xerrors.Errorf("failed to resume: %w", err)
+	}
+	return res.Body.Close()
+}
+
+func (c *Client) Reload(ctx context.Context) error {
+	res, err := c.do(ctx, http.MethodPost, "/reload")
+	if err != nil {
+		return xerrors.Errorf("failed to reload: %w", err)
+	}
+	return res.Body.Close()
+}
+
+// Logs writes recent logs to w. If follow is true, it keeps writing new logs until ctx is done.
+func (c *Client) Logs(ctx context.Context, w io.Writer, follow bool) error {
+	path := "/logs"
+	if follow {
+		path += "?follow=true"
+	}
+	res, err := c.do(ctx, http.MethodGet, path)
+	if err != nil {
+		return xerrors.Errorf("failed to get logs: %w", err)
+	}
+	defer res.Body.Close()
+
+	if _, err := io.Copy(w, res.Body); err != nil && ctx.Err() == nil {
+		return xerrors.Errorf("failed to read logs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package control
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sawadashota/unifi-doorbell-chime/listener"
+	"github.com/sawadashota/unifi-doorbell-chime/supervisor"
+	"github.com/sawadashota/unifi-doorbell-chime/x/logbuffer"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// Server serves control API on Unix domain socket
+type Server struct {
+	r      Registry
+	c      Configuration
+	logger logrus.FieldLogger
+}
+
+// Status is state of daemon
+type Status struct {
+	Services []supervisor.Status `json:"services"`
+	Listener listener.Status     `json:"listener"`
+}
+
+type Registry interface {
+	AppLogger(app string) logrus.FieldLogger
+	Listener() *listener.Listener
+	LogBuffer() *logbuffer.Buffer
+	Supervisor() *supervisor.Supervisor
+}
+
+type Configuration interface {
+	ControlSocket() string
+	Reload() error
+	ShutdownTimeoutSec() int
+}
+
+func New(r Registry, c Configuration) *Server {
+	return &Server{
+		r:      r,
+		c:      c,
+		logger: r.AppLogger("control"),
+	}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	path := s.c.ControlSocket()
+	ln, err := listen(path)
+	if err != nil {
+		return xerrors.Errorf("failed to listen control socket: %w", err)
+	}
+	defer os.Remove(path)
+
+	m := mux.NewRouter()
+	m.HandleFunc("/status", s.status).Methods(http.MethodGet)
+	m.HandleFunc("/pause", s.pause).Methods(http.MethodPost)
+	m.HandleFunc("/resume", s.resume).Methods(http.MethodPost)
+	m.HandleFunc("/reload", s.reload).Methods(http.MethodPost)
+	m.HandleFunc("/logs", s.logs).Methods(http.MethodGet)
+	svr := &http.Server{
+		Handler: m,
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		s.logger.Infof("start control server. %s", path)
+		if err := svr.Serve(ln); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		s.logger.Info("Bye!")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(s.c.ShutdownTimeoutSec())*time.Second)
+		defer cancel()
+		return svr.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		s.logger.Debugf("%+v", err)
+		return xerrors.Errorf("exit control server: %w", err)
+	}
+}
+
+// listen listens Unix domain socket accessible only by owner.
+// Socket left by crashed daemon is removed but socket of running daemon is not.
+func listen(path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			_ = conn.Close()
+			return nil, xerrors.Errorf("%s is used by another running instance", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		_ = ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		s.logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (s *Server) status(w http.ResponseWriter, _ *http.Request) {
+	s.writeJSON(w, http.StatusOK, &Status{
+		Services: s.r.Supervisor().Statuses(),
+		Listener: s.r.Listener().Status(),
+	})
+}
+
+func (s *Server) pause(w http.ResponseWriter, _ *http.Request) {
+	s.r.Listener().Pause()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) resume(w http.ResponseWriter, _ *http.Request) {
+	s.r.Listener().Resume()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) reload(w http.ResponseWriter, _ *http.Request) {
+	if err := s.c.Reload(); err != nil {
+		s.logger.Errorf("keep current config because failed to reload: %s", err)
+		s.writeJSON(w, http.StatusUnprocessableEntity, &errorResponse{Error: err.Error()})
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) logs(w http.ResponseWriter, r *http.Request) {
+	follow := r.URL.Query().Get("follow") == "true"
+
+	var (
+		recent []string
+		lines  <-chan string
+		stop   = func() {}
+	)
+	if follow {
+		recent, lines, stop = s.r.LogBuffer().Subscribe()
+	} else {
+		recent = s.r.LogBuffer().Lines()
+	}
+	defer stop()
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	for _, line := range recent {
+		_, _ = fmt.Fprint(w, line)
+	}
+	if !follow {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case line, ok := <-lines:
+			if !ok {
+				return
+			}
+			if _, err := fmt.Fprint(w, line); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,537 @@
+package configuration
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/sawadashota/unifi-doorbell-chime/chime"
+	"github.com/sawadashota/unifi-doorbell-chime/x/apiauth"
+	"github.com/sawadashota/unifi-doorbell-chime/x/netenv"
+	"github.com/sawadashota/unifi-doorbell-chime/x/tlsconfig"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/xerrors"
+)
+
+// Config is typed representation of config file used to validate it
+type Config struct {
+	Log struct {
+		Level string `mapstructure:"level"`
+	} `mapstructure:"log"`
+
+	Unifi struct {
+		SkipTLSVerify *bool  `mapstructure:"skip_tls_verify"`
+		IP            string `mapstructure:"ip"`
+		Username      string `mapstructure:"username"`
+		Password      string `mapstructure:"password"`
+		PasswordFile  string `mapstructure:"password_file"`
+	} `mapstructure:"unifi"`
+
+	Doorbells struct {
+		Include []string `mapstructure:"include"`
+		Exclude []string `mapstructure:"exclude"`
+	} `mapstructure:"doorbells"`
+
+	Secret struct {
+		Keyring bool `mapstructure:"keyring"`
+	} `mapstructure:"secret"`
+
+	Web struct {
+		Port      int    `mapstructure:"port"`
+		Bind      string `mapstructure:"bind"`
+		BasePath  string `mapstructure:"base_path"`
+		Dashboard struct {
+			SnapshotIntervalSec int `mapstructure:"snapshot_interval_sec"`
+		} `mapstructure:"dashboard"`
+	} `mapstructure:"web"`
+
+	API struct {
+		Port int    `mapstructure:"port"`
+		Bind string `mapstructure:"bind"`
+		Auth struct {
+			Enabled        *bool           `mapstructure:"enabled"`
+			TrustLoopback  *bool           `mapstructure:"trust_loopback"`
+			TrustedProxies []string        `mapstructure:"trusted_proxies"`
+			Tokens         []apiauth.Token `mapstructure:"tokens"`
+			Users          []apiauth.User  `mapstructure:"users"`
+			SessionTTLSec  int             `mapstructure:"session_ttl_sec"`
+		} `mapstructure:"auth"`
+		CORS struct {
+			AllowedOrigins []string `mapstructure:"allowed_origins"`
+		} `mapstructure:"cors"`
+	} `mapstructure:"api"`
+
+	TLS struct {
+		Mode       string `mapstructure:"mode"`
+		CertFile   string `mapstructure:"cert_file"`
+		KeyFile    string `mapstructure:"key_file"`
+		SelfSigned struct {
+			Dir   string   `mapstructure:"dir"`
+			Hosts []string `mapstructure:"hosts"`
+		} `mapstructure:"self_signed"`
+		ACME struct {
+			DirectoryURL string   `mapstructure:"directory_url"`
+			Email        string   `mapstructure:"email"`
+			Domains      []string `mapstructure:"domains"`
+			CacheDir     string   `mapstructure:"cache_dir"`
+			HTTPAddr     string   `mapstructure:"http_addr"`
+		} `mapstructure:"acme"`
+		HSTSMaxAgeSec int `mapstructure:"hsts_max_age_sec"`
+	} `mapstructure:"tls"`
+
+	Stream struct {
+		Source     string `mapstructure:"source"`
+		FFmpegPath string `mapstructure:"ffmpeg_path"`
+		FPS        int    `mapstructure:"fps"`
+	} `mapstructure:"stream"`
+
+	Talkback struct {
+		Encoder string `mapstructure:"encoder"`
+		Address string `mapstructure:"address"`
+	} `mapstructure:"talkback"`
+
+	Clip struct {
+		Enabled        bool   `mapstructure:"enabled"`
+		Dir            string `mapstructure:"dir"`
+		MaxDiskUsageMB int    `mapstructure:"max_disk_usage_mb"`
+		PreSec         int    `mapstructure:"pre_sec"`
+		PostSec        int    `mapstructure:"post_sec"`
+	} `mapstructure:"clip"`
+
+	Message struct {
+		Templates []string `mapstructure:"templates"`
+	} `mapstructure:"message"`
+
+	Chime struct {
+		DND struct {
+			Enabled bool   `mapstructure:"enabled"`
+			Start   string `mapstructure:"start"`
+			End     string `mapstructure:"end"`
+		} `mapstructure:"dnd"`
+		StateFile string `mapstructure:"state_file"`
+	} `mapstructure:"chime"`
+
+	Control struct {
+		Socket string `mapstructure:"socket"`
+	} `mapstructure:"control"`
+
+	Supervisor struct {
+		Restart       string                       `mapstructure:"restart"`
+		MaxRestarts   int                          `mapstructure:"max_restarts"`
+		BackoffMaxSec int                          `mapstructure:"backoff_max_sec"`
+		Services      map[string]ServiceSupervisor `mapstructure:"services"`
+	} `mapstructure:"supervisor"`
+
+	BootOption struct {
+		MacAddress string `mapstructure:"mac_address"`
+	} `mapstructure:"boot_option"`
+
+	Network struct {
+		Networks         []netenv.Network `mapstructure:"networks"`
+		CheckIntervalSec int              `mapstructure:"check_interval_sec"`
+	} `mapstructure:"network"`
+
+	ShutdownTimeoutSec int `mapstructure:"shutdown_timeout_sec"`
+}
+
+// ServiceSupervisor overrides supervisor config of a service
+type ServiceSupervisor struct {
+	Restart     string `mapstructure:"restart"`
+	MaxRestarts int    `mapstructure:"max_restarts"`
+}
+
+// FieldError is validation error of a config key
+type FieldError struct {
+	Key     string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Key, e.Message)
+}
+
+// ValidationErrors holds every invalid field of config
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fe := range e {
+		msgs = append(msgs, fe.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e *ValidationErrors) add(key, format string, args ...interface{}) {
+	*e = append(*e, FieldError{
+		Key:     key,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// LoadConfig decodes config of viper instance to Config
+func LoadConfig(v *viper.Viper) (*Config, error) {
+	var c Config
+	if err := v.Unmarshal(&c); err != nil {
+		return nil, xerrors.Errorf("failed to decode config: %w", err)
+	}
+	return &c, nil
+}
+
+// Validate returns ValidationErrors if config has invalid fields
+func (c *Config) Validate() error {
+	var errs ValidationErrors
+
+	if c.Log.Level != "" {
+		if _, err := logrus.ParseLevel(c.Log.Level); err != nil {
+			errs.add(viperLogLevel, "unknown level %q. use one of trace, debug, info, warn, error", c.Log.Level)
+		}
+	}
+
+	switch {
+	case c.Unifi.IP == "":
+		errs.add(viperUnifiIp, "is required. set IP address or host name of UniFi Protect controller")
+	case strings.Contains(c.Unifi.IP, "://") || strings.Contains(c.Unifi.IP, "/"):
+		errs.add(viperUnifiIp, "must be IP address or host name without scheme and path. got %q", c.Unifi.IP)
+	}
+	if c.Unifi.Username == "" {
+		errs.add(viperUnifiUsername, "is required")
+	}
+
+	validatePort(&errs, viperWebPort, c.Web.Port)
+	validatePort(&errs, viperAPIPort, c.API.Port)
+	if webPort := c.Web.Port; c.API.Port != 0 && (c.API.Port == webPort || webPort == 0 && c.API.Port == DefaultWebPort) {
+		errs.add(viperAPIPort, "must be different from %s", viperWebPort)
+	}
+	if p := c.Web.BasePath; p != "" && (!strings.HasPrefix(p, "/") || strings.ContainsAny(p, "?# ")) {
+		errs.add(viperWebBasePath, "must be path starting with / like /doorbell. got %q", p)
+	}
+	validateBind(&errs, viperWebBind, c.Web.Bind)
+	validateBind(&errs, viperAPIBind, c.API.Bind)
+	c.validateAPIAuth(&errs)
+	c.validateTLS(&errs)
+	if c.Web.Dashboard.SnapshotIntervalSec < 0 {
+		errs.add(viperDashboardSnapshotIntervalSec, "must not be negative")
+	}
+
+	switch c.Stream.Source {
+	case "", "ffmpeg", "fake":
+	default:
+		errs.add(viperStreamSource, "unknown source %q. use ffmpeg or fake", c.Stream.Source)
+	}
+	if c.Stream.FPS < 0 || c.Stream.FPS > 60 {
+		errs.add(viperStreamFPS, "must be between 1 and 60, or 0 for default. got %d", c.Stream.FPS)
+	}
+
+	switch c.Talkback.Encoder {
+	case "", "ffmpeg", "pcm":
+	default:
+		errs.add(viperTalkbackEncoder, "unknown encoder %q. use ffmpeg or pcm", c.Talkback.Encoder)
+	}
+	if c.Talkback.Address != "" {
+		if _, _, err := net.SplitHostPort(c.Talkback.Address); err != nil {
+			errs.add(viperTalkbackAddress, "must be host:port. got %q", c.Talkback.Address)
+		}
+	}
+
+	if c.Clip.MaxDiskUsageMB < 0 {
+		errs.add(viperClipMaxDiskUsageMB, "must not be negative")
+	}
+	if c.Clip.PreSec < 0 {
+		errs.add(viperClipPreSec, "must not be negative")
+	}
+	if c.Clip.PostSec < 0 {
+		errs.add(viperClipPostSec, "must not be negative")
+	}
+
+	if len(c.Message.Templates) == 0 {
+		errs.add(viperMessageTemplates, "must have at least one message")
+	}
+	for i, t := range c.Message.Templates {
+		if strings.TrimSpace(t) == "" {
+			errs.add(fmt.Sprintf("%s[%d]", viperMessageTemplates, i), "must not be empty")
+		}
+	}
+
+	c.validateChime(&errs)
+
+	validateRestartPolicy(&errs, viperSupervisorRestart, c.Supervisor.Restart)
+	if c.Supervisor.MaxRestarts < 0 {
+		errs.add(viperSupervisorMaxRestarts, "must not be negative")
+	}
+	if c.Supervisor.BackoffMaxSec < 0 {
+		errs.add(viperSupervisorBackoffMaxSec, "must not be negative")
+	}
+	for name, s := range c.Supervisor.Services {
+		key := viperSupervisorServices + "." + name
+		validateRestartPolicy(&errs, key+".restart", s.Restart)
+		if s.MaxRestarts < 0 {
+			errs.add(key+".max_restarts", "must not be negative")
+		}
+	}
+
+	if c.BootOption.MacAddress != "" {
+		if _, err := net.ParseMAC(c.BootOption.MacAddress); err != nil {
+			errs.add(viperBootOptionMacAddress, "invalid MAC address %q. format is like 00:00:5e:00:53:01", c.BootOption.MacAddress)
+		}
+	}
+
+	for i, n := range c.Network.Networks {
+		key := fmt.Sprintf("%s[%d]", viperNetworkNetworks, i)
+		if n.IsEmpty() {
+			errs.add(key, "must have at least one of ssid, gateway_mac, cidr, nvr_reachable or interface_mac")
+		}
+		if n.GatewayMAC != "" {
+			if _, err := net.ParseMAC(n.GatewayMAC); err != nil {
+				errs.add(key+".gateway_mac", "invalid MAC address %q", n.GatewayMAC)
+			}
+		}
+		if n.InterfaceMAC != "" {
+			if _, err := net.ParseMAC(n.InterfaceMAC); err != nil {
+				errs.add(key+".interface_mac", "invalid MAC address %q", n.InterfaceMAC)
+			}
+		}
+		if n.CIDR != "" {
+			if _, _, err := net.ParseCIDR(n.CIDR); err != nil {
+				errs.add(key+".cidr", "invalid CIDR %q. format is like 192.168.1.0/24", n.CIDR)
+			}
+		}
+	}
+	if c.Network.CheckIntervalSec < 0 {
+		errs.add(viperNetworkCheckIntervalSec, "must not be negative")
+	}
+
+	if c.ShutdownTimeoutSec < 0 {
+		errs.add(viperShutdownTimeoutSec, "must not be negative")
+	}
+
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool {
+			return errs[i].Key < errs[j].Key
+		})
+		return errs
+	}
+	return nil
+}
+
+// NormalizeBasePath returns path prefix without trailing slash. / is regarded as empty.
+func NormalizeBasePath(p string) string {
+	p = strings.TrimRight(p, "/")
+	if p != "" && !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return p
+}
+
+func validateBind(errs *ValidationErrors, key string, bind string) {
+	if strings.ContainsAny(bind, ":/ ") && net.ParseIP(bind) == nil {
+		errs.add(key, "must be IP address or host name without port. got %q", bind)
+	}
+}
+
+func (c *Config) validateTLS(errs *ValidationErrors) {
+	switch c.TLS.Mode {
+	case "", tlsconfig.ModeOff, tlsconfig.ModeSelfSigned:
+	case tlsconfig.ModeFiles:
+		if c.TLS.CertFile == "" {
+			errs.add(viperTLSCertFile, "is required when %s is %s", viperTLSMode, tlsconfig.ModeFiles)
+		}
+		if c.TLS.KeyFile == "" {
+			errs.add(viperTLSKeyFile, "is required when %s is %s", viperTLSMode, tlsconfig.ModeFiles)
+		}
+	case tlsconfig.ModeACME:
+		if len(c.TLS.ACME.Domains) == 0 {
+			errs.add(viperTLSACMEDomains, "must have at least one domain when %s is %s", viperTLSMode, tlsconfig.ModeACME)
+		}
+		if c.TLS.ACME.DirectoryURL == "" {
+			errs.add(viperTLSACMEDirectoryURL, "is required when %s is %s. e.g. https://ca.home.lan/acme/acme/directory or %s", viperTLSMode, tlsconfig.ModeACME, acme.LetsEncryptURL)
+		} else if u, err := url.Parse(c.TLS.ACME.DirectoryURL); err != nil || u.Scheme != "https" && u.Scheme != "http" || u.Host == "" {
+			errs.add(viperTLSACMEDirectoryURL, "must be URL like https://acme.example.com/directory. got %q", c.TLS.ACME.DirectoryURL)
+		}
+		if c.TLS.ACME.HTTPAddr != "" {
+			if _, _, err := net.SplitHostPort(c.TLS.ACME.HTTPAddr); err != nil {
+				errs.add(viperTLSACMEHTTPAddr, "must be host:port like :80. got %q", c.TLS.ACME.HTTPAddr)
+			}
+		}
+	default:
+		errs.add(viperTLSMode, "unknown mode %q. use one of %s", c.TLS.Mode, strings.Join(tlsconfig.Modes, ", "))
+	}
+	for i, h := range c.TLS.SelfSigned.Hosts {
+		if h == "" || strings.ContainsAny(h, ":/ ") && net.ParseIP(h) == nil {
+			errs.add(fmt.Sprintf("%s[%d]", viperTLSSelfSignedHosts, i), "must be IP address or host name. got %q", h)
+		}
+	}
+}
+
+func (c *Config) validateAPIAuth(errs *ValidationErrors) {
+	names := make(map[string]bool)
+	for i, t := range c.API.Auth.Tokens {
+		key := fmt.Sprintf("%s[%d]", viperAPIAuthTokens, i)
+		if t.Name == "" {
+			errs.add(key+".name", "is required")
+		} else if names[t.Name] {
+			errs.add(key+".name", "%q is duplicated", t.Name)
+		}
+		names[t.Name] = true
+		if t.Hash == "" {
+			errs.add(key+".hash", "is required. generate token by `unifi-doorbell-chime auth token`")
+		}
+		validateScopes(errs, key+".scopes", t.Scopes)
+	}
+
+	usernames := make(map[string]bool)
+	for i, u := range c.API.Auth.Users {
+		key := fmt.Sprintf("%s[%d]", viperAPIAuthUsers, i)
+		if u.Username == "" {
+			errs.add(key+".username", "is required")
+		} else if usernames[u.Username] {
+			errs.add(key+".username", "%q is duplicated", u.Username)
+		}
+		usernames[u.Username] = true
+		if !strings.HasPrefix(u.PasswordHash, "$2") {
+			errs.add(key+".password_hash", "must be bcrypt hash. generate it by `unifi-doorbell-chime auth hash-password`")
+		}
+		validateScopes(errs, key+".scopes", u.Scopes)
+	}
+
+	if c.API.Auth.SessionTTLSec < 0 {
+		errs.add(viperAPIAuthSessionTTLSec, "must not be negative")
+	}
+
+	for i, p := range c.API.Auth.TrustedProxies {
+		if _, _, err := net.ParseCIDR(p); err != nil && net.ParseIP(p) == nil {
+			errs.add(fmt.Sprintf("%s[%d]", viperAPIAuthTrustedProxies, i), "must be IP address or CIDR. got %q", p)
+		}
+	}
+	// reverse proxy on the same host connects from loopback on behalf of everyone
+	if c.API.Auth.TrustLoopback != nil && *c.API.Auth.TrustLoopback && NormalizeBasePath(c.Web.BasePath) != "" && len(c.API.Auth.TrustedProxies) == 0 {
+		errs.add(viperAPIAuthTrustLoopback, "requires %s when %s is set for reverse proxy", viperAPIAuthTrustedProxies, viperWebBasePath)
+	}
+
+	for i, origin := range c.API.CORS.AllowedOrigins {
+		key := fmt.Sprintf("%s[%d]", viperAPICORSAllowedOrigins, i)
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			errs.add(key, "must be origin like https://example.com:8443. got %q", origin)
+		}
+	}
+}
+
+func validateScopes(errs *ValidationErrors, key string, scopes []string) {
+	if len(scopes) == 0 {
+		errs.add(key, "must have at least one of read, message, settings or admin")
+	}
+	if _, err := apiauth.ParseScopes(scopes); err != nil {
+		errs.add(key, "%s", err)
+	}
+}
+
+func validatePort(errs *ValidationErrors, key string, port int) {
+	if port < 0 || port > 65535 {
+		errs.add(key, "must be between 1 and 65535. got %d", port)
+	}
+}
+
+func validateRestartPolicy(errs *ValidationErrors, key string, policy string) {
+	switch policy {
+	case "", "always", "on-failure", "never":
+	default:
+		errs.add(key, "unknown restart policy %q. use always, on-failure or never", policy)
+	}
+}
+
+// validate validates config of viper instance including secrets
+func (v *ViperProvider) validate(in *viper.Viper) error {
+	c, err := LoadConfig(in)
+	if err != nil {
+		return err
+	}
+
+	var errs ValidationErrors
+	if err := c.Validate(); err != nil {
+		errs = append(errs, err.(ValidationErrors)...)
+	}
+
+	password, err := v.resolveSecret(in, viperUnifiPassword)
+	switch {
+	case err != nil:
+		errs.add(viperUnifiPassword, "%s", err)
+	case password == "":
+		errs.add(viperUnifiPassword, "is required. set it in config file, %s, %s_file or OS keyring", EnvKey(viperUnifiPassword), viperUnifiPassword)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Validate validates current config
+func (v *ViperProvider) Validate() error {
+	return v.validate(v.instance())
+}
+
+var secretKeySuffixes = []string{"password", "token", "secret", "hash"}
+
+// Redacted returns current settings with secret values masked
+func (v *ViperProvider) Redacted() map[string]interface{} {
+	return redact(v.instance().AllSettings())
+}
+
+func redact(settings map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(settings))
+	for k, value := range settings {
+		if m, ok := value.(map[string]interface{}); ok {
+			out[k] = redact(m)
+			continue
+		}
+		if list, ok := value.([]interface{}); ok {
+			redacted := make([]interface{}, 0, len(list))
+			for _, item := range list {
+				switch m := item.(type) {
+				case map[string]interface{}:
+					item = redact(m)
+				case map[interface{}]interface{}:
+					item = redact(cast.ToStringMap(m))
+				}
+				redacted = append(redacted, item)
+			}
+			out[k] = redacted
+			continue
+		}
+
+		out[k] = value
+		for _, suffix := range secretKeySuffixes {
+			if strings.HasSuffix(strings.ToLower(k), suffix) && value != "" {
+				out[k] = "<redacted>"
+			}
+		}
+	}
+	return out
+}
+
+func (c *Config) validateChime(errs *ValidationErrors) {
+	start, end := c.Chime.DND.Start, c.Chime.DND.End
+	if start == "" {
+		start = defaultChimeDNDStart
+	}
+	if end == "" {
+		end = defaultChimeDNDEnd
+	}
+	startMin, startErr := chime.ParseClock(start)
+	if startErr != nil {
+		errs.add(viperChimeDNDStart, "must be time of day like 22:00. got %q", start)
+	}
+	endMin, endErr := chime.ParseClock(end)
+	if endErr != nil {
+		errs.add(viperChimeDNDEnd, "must be time of day like 07:00. got %q", end)
+	}
+	// DND would never be active
+	if c.Chime.DND.Enabled && startErr == nil && endErr == nil && startMin == endMin {
+		errs.add(viperChimeDNDEnd, "must be different from %s", viperChimeDNDStart)
+	}
+}
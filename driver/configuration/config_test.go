@@ -0,0 +1,94 @@
+package configuration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// validConfig is minimum config which passes validation
+const validConfig = `
+unifi:
+  ip: 192.168.1.1
+  username: doorbell
+message:
+  templates: [Just a moment]
+`
+
+func validate(t *testing.T, yaml string) ValidationErrors {
+	t.Helper()
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(yaml)); err != nil {
+		t.Fatal(err)
+	}
+	c, err := LoadConfig(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Validate(); err != nil {
+		return err.(ValidationErrors)
+	}
+	return nil
+}
+
+func TestConfigValidate(t *testing.T) {
+	if errs := validate(t, validConfig); errs != nil {
+		t.Fatalf("minimum config is invalid: %s", errs)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		config string
+		key    string
+	}{
+		{name: "no unifi ip", config: "unifi: {username: doorbell}\nmessage: {templates: [hi]}", key: viperUnifiIp},
+		{name: "unifi ip with scheme", config: "unifi: {ip: 'https://192.168.1.1', username: doorbell}\nmessage: {templates: [hi]}", key: viperUnifiIp},
+		{name: "negative web port", config: validConfig + "web: {port: -1}", key: viperWebPort},
+		{name: "too large web port", config: validConfig + "web: {port: 65536}", key: viperWebPort},
+		{name: "too large api port", config: validConfig + "api: {port: 70000}", key: viperAPIPort},
+		{name: "api port same as web port", config: validConfig + "web: {port: 8081}\napi: {port: 8081}", key: viperAPIPort},
+		{name: "negative fps", config: validConfig + "stream: {fps: -1}", key: viperStreamFPS},
+		{name: "too large fps", config: validConfig + "stream: {fps: 61}", key: viperStreamFPS},
+		{name: "invalid mac address", config: validConfig + "boot_option: {mac_address: '00:00:5e:00:53'}", key: viperBootOptionMacAddress},
+		{name: "empty templates", config: "unifi: {ip: 192.168.1.1, username: doorbell}\nmessage: {templates: []}", key: viperMessageTemplates},
+		{name: "blank template", config: "unifi: {ip: 192.168.1.1, username: doorbell}\nmessage: {templates: ['  ']}", key: viperMessageTemplates + "[0]"},
+		{name: "negative clip pre sec", config: validConfig + "clip: {pre_sec: -1}", key: viperClipPreSec},
+		{name: "negative shutdown timeout", config: validConfig + "shutdown_timeout_sec: -1", key: viperShutdownTimeoutSec},
+		{name: "invalid dnd start", config: validConfig + "chime: {dnd: {start: '10pm'}}", key: viperChimeDNDStart},
+		{name: "invalid dnd end", config: validConfig + "chime: {dnd: {end: '25:00'}}", key: viperChimeDNDEnd},
+		{name: "empty dnd window", config: validConfig + "chime: {dnd: {enabled: true, start: '07:00'}}", key: viperChimeDNDEnd},
+		{name: "invalid trusted proxy", config: validConfig + "api: {auth: {trusted_proxies: [proxy.local]}}", key: viperAPIAuthTrustedProxies + "[0]"},
+		{name: "trust loopback behind proxy", config: validConfig + "web: {base_path: /doorbell}\napi: {auth: {trust_loopback: true}}", key: viperAPIAuthTrustLoopback},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validate(t, tc.config)
+			for _, fe := range errs {
+				if fe.Key == tc.key {
+					return
+				}
+			}
+			t.Errorf("%s is not rejected: %v", tc.key, errs)
+		})
+	}
+
+	for _, tc := range []struct {
+		name   string
+		config string
+	}{
+		{name: "default fps", config: validConfig + "stream: {fps: 0}"},
+		{name: "max fps", config: validConfig + "stream: {fps: 60}"},
+		{name: "api server disabled", config: validConfig + "api: {port: 0}"},
+		{name: "mac address", config: validConfig + "boot_option: {mac_address: '00:00:5e:00:53:01'}"},
+		{name: "dnd across midnight", config: validConfig + "chime: {dnd: {enabled: true, start: '23:30', end: '06:00'}}"},
+		{name: "trust loopback", config: validConfig + "api: {auth: {trust_loopback: true}}"},
+		{name: "trust loopback behind trusted proxy", config: validConfig + "web: {base_path: /doorbell}\napi: {auth: {trust_loopback: true, trusted_proxies: [127.0.0.1, 10.0.0.0/24]}}"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if errs := validate(t, tc.config); errs != nil {
+				t.Errorf("valid config is rejected: %s", errs)
+			}
+		})
+	}
+}
@@ -1,17 +1,84 @@
 package configuration
 
+import (
+	"github.com/sawadashota/unifi-doorbell-chime/x/apiauth"
+	"github.com/sawadashota/unifi-doorbell-chime/x/netenv"
+)
+
 type Provider interface {
+	Reload() error
+	Subscribe(fn func())
+	WatchConfig(onError func(err error))
+	Validate() error
+	Redacted() map[string]interface{}
+
 	LogLevel() string
 
 	UnifiSkipTLSVerify() bool
 	UnifiIp() string
 	UnifiUsername() string
-	UnifiPassword() string
+	UnifiPassword() (string, error)
+
+	DoorbellsInclude() []string
+	DoorbellsExclude() []string
 
 	WebPort() int
+	WebBind() string
+	WebBasePath() string
 	APIPort() int
+	APIBind() string
+
+	APIAuthEnabled() bool
+	APIAuthTrustLoopback() bool
+	APIAuthTrustedProxies() []string
+	APITokens() []apiauth.Token
+	APIUsers() []apiauth.User
+	APISessionTTLSec() int
+	APICORSAllowedOrigins() []string
+
+	TLSMode() string
+	TLSCertFile() string
+	TLSKeyFile() string
+	TLSSelfSignedDir() string
+	TLSSelfSignedHosts() []string
+	TLSACMEDirectoryURL() string
+	TLSACMEEmail() string
+	TLSACMEDomains() []string
+	TLSACMECacheDir() string
+	TLSACMEHTTPAddr() string
+	TLSHSTSMaxAgeSec() int
+
+	DashboardSnapshotIntervalSec() int
+
+	StreamSource() string
+	StreamFFmpegPath() string
+	StreamFPS() int
+
+	TalkbackEncoder() string
+	TalkbackAddress() string
+
+	ClipEnabled() bool
+	ClipDir() string
+	ClipMaxDiskUsageMB() int
+	ClipPreSec() int
+	ClipPostSec() int
 
 	MessageList() []string
 
+	ChimeDNDEnabled() bool
+	ChimeDNDStart() string
+	ChimeDNDEnd() string
+	ChimeStateFile() string
+
+	ControlSocket() string
+
+	SupervisorRestartPolicy(service string) string
+	SupervisorMaxRestarts(service string) int
+	SupervisorBackoffMaxSec() int
+
 	BootOptionMacAddress() string
+	Networks() []netenv.Network
+	NetworkCheckIntervalSec() int
+
+	ShutdownTimeoutSec() int
 }
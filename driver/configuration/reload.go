@@ -0,0 +1,66 @@
+package configuration
+
+import (
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"golang.org/x/xerrors"
+)
+
+// keys which are fixed while running because servers are already listening on them
+var runtimeKeys = []string{
+	viperWebPort,
+	viperWebBasePath,
+	viperAPIPort,
+	viperControlSocket,
+}
+
+// Reload reads config file again and applies it only when it is valid.
+// Invalid config is discarded and the current config keeps being used.
+func (v *ViperProvider) Reload() error {
+	current := v.instance()
+
+	next := viper.New()
+	next.SetConfigFile(current.ConfigFileUsed())
+	if err := next.ReadInConfig(); err != nil {
+		return xerrors.Errorf("failed to read config file: %w", err)
+	}
+	if err := v.validate(next); err != nil {
+		return xerrors.Errorf("invalid config: %w", err)
+	}
+	for _, key := range runtimeKeys {
+		if current.IsSet(key) {
+			next.Set(key, current.Get(key))
+		}
+	}
+
+	v.mu.Lock()
+	v.v = next
+	subscribers := make([]func(), len(v.subscribers))
+	copy(subscribers, v.subscribers)
+	v.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn()
+	}
+	return nil
+}
+
+// Subscribe registers fn called after config is reloaded
+func (v *ViperProvider) Subscribe(fn func()) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.subscribers = append(v.subscribers, fn)
+}
+
+// WatchConfig reloads config whenever config file is changed.
+// onError is called when reloading failed and the current config is kept.
+func (v *ViperProvider) WatchConfig(onError func(err error)) {
+	w := viper.New()
+	w.SetConfigFile(v.instance().ConfigFileUsed())
+	w.OnConfigChange(func(fsnotify.Event) {
+		if err := v.Reload(); err != nil {
+			onError(err)
+		}
+	})
+	w.WatchConfig()
+}
@@ -0,0 +1,117 @@
+package configuration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sawadashota/unifi-doorbell-chime/x/keyring"
+	"github.com/spf13/viper"
+	"golang.org/x/xerrors"
+)
+
+const (
+	envPrefix = "UDC_"
+
+	keyringService = "unifi-doorbell-chime"
+
+	viperSecretKeyring = "secret.keyring"
+)
+
+// SecretResolver resolves secret of config key (e.g. "unifi.password") from outside of config file.
+// It returns false if it has nothing for the key so that the next resolver is tried.
+type SecretResolver interface {
+	Resolve(v *viper.Viper, key string) (string, bool, error)
+}
+
+// DefaultSecretResolvers resolves secrets in order of environment variable, file and OS keyring
+func DefaultSecretResolvers() []SecretResolver {
+	return []SecretResolver{
+		new(EnvSecretResolver),
+		new(FileSecretResolver),
+		new(KeyringSecretResolver),
+	}
+}
+
+// EnvKey returns environment variable name of config key. e.g. unifi.password -> UDC_UNIFI_PASSWORD
+func EnvKey(key string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// EnvSecretResolver reads environment variable such as UDC_UNIFI_PASSWORD
+type EnvSecretResolver struct{}
+
+func (r *EnvSecretResolver) Resolve(_ *viper.Viper, key string) (string, bool, error) {
+	value, ok := os.LookupEnv(EnvKey(key))
+	if !ok || value == "" {
+		return "", false, nil
+	}
+	return value, true, nil
+}
+
+// FileSecretResolver reads file referred by `<key>_file` config (e.g. unifi.password_file)
+// or UDC_<KEY>_FILE environment variable (Docker secrets),
+// or credential named after the key in $CREDENTIALS_DIRECTORY (systemd LoadCredential=).
+type FileSecretResolver struct{}
+
+func (r *FileSecretResolver) Resolve(v *viper.Viper, key string) (string, bool, error) {
+	path := v.GetString(key + "_file")
+	if path == "" {
+		path = os.Getenv(EnvKey(key) + "_FILE")
+	}
+	if path == "" {
+		if dir := os.Getenv("CREDENTIALS_DIRECTORY"); dir != "" {
+			if p := filepath.Join(dir, key); fileExists(p) {
+				path = p
+			}
+		}
+	}
+	if path == "" {
+		return "", false, nil
+	}
+
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", false, xerrors.Errorf("failed to read secret file of %s: %w", key, err)
+	}
+	return strings.TrimRight(string(b), "\r\n"), true, nil
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// KeyringSecretResolver reads OS keyring when `secret.keyring` is true
+type KeyringSecretResolver struct{}
+
+func (r *KeyringSecretResolver) Resolve(v *viper.Viper, key string) (string, bool, error) {
+	if !v.GetBool(viperSecretKeyring) {
+		return "", false, nil
+	}
+	value, err := keyring.Get(keyringService, key)
+	if err != nil {
+		return "", false, xerrors.Errorf("failed to resolve %s from keyring: %w", key, err)
+	}
+	return value, true, nil
+}
+
+// StoreSecretInKeyring saves secret of config key into OS keyring
+func StoreSecretInKeyring(key, secret string) error {
+	return keyring.Set(keyringService, key, secret)
+}
+
+// resolveSecret resolves secret by resolvers and falls back to plain value in config file
+func (v *ViperProvider) resolveSecret(in *viper.Viper, key string) (string, error) {
+	for _, r := range v.resolvers {
+		value, ok, err := r.Resolve(in, key)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return value, nil
+		}
+	}
+	return in.GetString(key), nil
+}
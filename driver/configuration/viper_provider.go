@@ -1,11 +1,22 @@
 package configuration
 
 import (
-	"github.com/phayes/freeport"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sawadashota/unifi-doorbell-chime/x/apiauth"
+	"github.com/sawadashota/unifi-doorbell-chime/x/netenv"
+	"github.com/sawadashota/unifi-doorbell-chime/x/tlsconfig"
 	"github.com/spf13/viper"
 )
 
-type ViperProvider struct{}
+type ViperProvider struct {
+	mu          sync.RWMutex
+	v           *viper.Viper
+	subscribers []func()
+	resolvers   []SecretResolver
+}
 
 var _ Provider = new(ViperProvider)
 
@@ -17,80 +28,394 @@ const (
 	viperUnifiUsername      = "unifi.username"
 	viperUnifiPassword      = "unifi.password"
 
+	viperDoorbellsInclude = "doorbells.include"
+	viperDoorbellsExclude = "doorbells.exclude"
+
 	viperWebPort = "web.port"
-	viperAPIPort = "api.port"
+	viperWebBind = "web.bind"
+
+	viperWebBasePath = "web.base_path"
+	viperAPIPort     = "api.port"
+	viperAPIBind     = "api.bind"
+
+	viperAPIAuthEnabled        = "api.auth.enabled"
+	viperAPIAuthTrustLoopback  = "api.auth.trust_loopback"
+	viperAPIAuthTrustedProxies = "api.auth.trusted_proxies"
+	viperAPIAuthTokens         = "api.auth.tokens"
+	viperAPIAuthUsers          = "api.auth.users"
+	viperAPIAuthSessionTTLSec  = "api.auth.session_ttl_sec"
+	viperAPICORSAllowedOrigins = "api.cors.allowed_origins"
+
+	viperTLSMode             = "tls.mode"
+	viperTLSCertFile         = "tls.cert_file"
+	viperTLSKeyFile          = "tls.key_file"
+	viperTLSSelfSignedDir    = "tls.self_signed.dir"
+	viperTLSSelfSignedHosts  = "tls.self_signed.hosts"
+	viperTLSACMEDirectoryURL = "tls.acme.directory_url"
+	viperTLSACMEEmail        = "tls.acme.email"
+	viperTLSACMEDomains      = "tls.acme.domains"
+	viperTLSACMECacheDir     = "tls.acme.cache_dir"
+	viperTLSACMEHTTPAddr     = "tls.acme.http_addr"
+	viperTLSHSTSMaxAgeSec    = "tls.hsts_max_age_sec"
+
+	viperDashboardSnapshotIntervalSec = "web.dashboard.snapshot_interval_sec"
+
+	viperStreamSource     = "stream.source"
+	viperStreamFFmpegPath = "stream.ffmpeg_path"
+	viperStreamFPS        = "stream.fps"
+
+	viperTalkbackEncoder = "talkback.encoder"
+	viperTalkbackAddress = "talkback.address"
+
+	viperClipEnabled        = "clip.enabled"
+	viperClipDir            = "clip.dir"
+	viperClipMaxDiskUsageMB = "clip.max_disk_usage_mb"
+	viperClipPreSec         = "clip.pre_sec"
+	viperClipPostSec        = "clip.post_sec"
 
 	viperMessageTemplates = "message.templates"
 
+	viperChimeDNDEnabled = "chime.dnd.enabled"
+	viperChimeDNDStart   = "chime.dnd.start"
+	viperChimeDNDEnd     = "chime.dnd.end"
+	viperChimeStateFile  = "chime.state_file"
+
+	viperControlSocket = "control.socket"
+
+	viperSupervisorRestart       = "supervisor.restart"
+	viperSupervisorMaxRestarts   = "supervisor.max_restarts"
+	viperSupervisorBackoffMaxSec = "supervisor.backoff_max_sec"
+	viperSupervisorServices      = "supervisor.services"
+
 	viperBootOptionMacAddress = "boot_option.mac_address"
+
+	viperNetworkNetworks         = "network.networks"
+	viperNetworkCheckIntervalSec = "network.check_interval_sec"
+
+	viperShutdownTimeoutSec = "shutdown_timeout_sec"
 )
 
-func getString(key string, defaultValue string) string {
-	v := viper.GetString(key)
-	if v == "" {
+func (v *ViperProvider) instance() *viper.Viper {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.v
+}
+
+func (v *ViperProvider) getString(key string, defaultValue string) string {
+	value := v.instance().GetString(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func (v *ViperProvider) getBool(key string, defaultValue bool) bool {
+	value := v.instance().Get(key)
+	if value == nil {
 		return defaultValue
 	}
-	return v
+	return v.instance().GetBool(key)
 }
 
-func getBool(key string, defaultValue bool) bool {
-	v := viper.Get(key)
-	if v == nil {
+func (v *ViperProvider) getInt(key string, defaultValue int) int {
+	value := v.instance().GetInt(key)
+	if value == 0 {
 		return defaultValue
 	}
-	return viper.GetBool(key)
+	return value
 }
 
-func NewViperProvider() Provider {
-	return &ViperProvider{}
+// NewViperProvider returns Provider reading config loaded to global viper instance.
+// Secrets are resolved by DefaultSecretResolvers if no resolver is given.
+func NewViperProvider(resolvers ...SecretResolver) Provider {
+	if len(resolvers) == 0 {
+		resolvers = DefaultSecretResolvers()
+	}
+	return &ViperProvider{
+		v:         viper.GetViper(),
+		resolvers: resolvers,
+	}
 }
 
 func (v *ViperProvider) LogLevel() string {
-	return getString(viperLogLevel, "info")
+	return v.getString(viperLogLevel, "info")
 }
 
 func (v *ViperProvider) UnifiSkipTLSVerify() bool {
-	return getBool(viperUnifiSkipTLSVerify, true)
+	return v.getBool(viperUnifiSkipTLSVerify, true)
 }
 
 func (v *ViperProvider) UnifiIp() string {
-	return viper.GetString(viperUnifiIp)
+	return v.instance().GetString(viperUnifiIp)
 }
 
 func (v *ViperProvider) UnifiUsername() string {
-	return viper.GetString(viperUnifiUsername)
+	return v.instance().GetString(viperUnifiUsername)
 }
 
-func (v *ViperProvider) UnifiPassword() string {
-	return viper.GetString(viperUnifiPassword)
+// UnifiPassword returns password of UniFi Protect resolved from secret sources like OS keyring.
+// Error tells the secret is unavailable, e.g. keyring is locked, rather than rejected by UniFi Protect.
+func (v *ViperProvider) UnifiPassword() (string, error) {
+	return v.resolveSecret(v.instance(), viperUnifiPassword)
 }
 
+func (v *ViperProvider) DoorbellsInclude() []string {
+	return v.instance().GetStringSlice(viperDoorbellsInclude)
+}
+
+func (v *ViperProvider) DoorbellsExclude() []string {
+	return v.instance().GetStringSlice(viperDoorbellsExclude)
+}
+
+// DefaultWebPort is port of web server unless web.port is set
+const DefaultWebPort = 8080
+
+// WebPort returns port of web server serving both ringing page and API
 func (v *ViperProvider) WebPort() int {
-	port := viper.GetInt(viperWebPort)
-	if port == 0 {
-		port, _ := freeport.GetFreePort()
-		viper.Set(viperWebPort, port)
-		return v.WebPort()
-	}
+	return v.getInt(viperWebPort, DefaultWebPort)
+}
 
-	return port
+// WebBasePath returns path prefix which web server is served under, e.g. /doorbell behind reverse proxy.
+// It is empty or starts with slash without trailing slash.
+func (v *ViperProvider) WebBasePath() string {
+	return NormalizeBasePath(v.instance().GetString(viperWebBasePath))
 }
 
+// APIPort returns port of deprecated standalone API server. 0 is disabled.
+// API is served by web server under /api/v1.
 func (v *ViperProvider) APIPort() int {
-	port := viper.GetInt(viperAPIPort)
-	if port == 0 {
-		port, _ := freeport.GetFreePort()
-		viper.Set(viperAPIPort, port)
-		return v.APIPort()
+	return v.instance().GetInt(viperAPIPort)
+}
+
+// defaultBind is address which servers listen on unless bind is set
+const defaultBind = "127.0.0.1"
+
+// WebBind returns address which web server listens on. Empty is all interfaces.
+// Unless it is set, only the host itself can reach web server and API.
+func (v *ViperProvider) WebBind() string {
+	return v.getString(viperWebBind, defaultBind)
+}
+
+// APIBind returns address which deprecated standalone API server listens on. Empty is all interfaces.
+func (v *ViperProvider) APIBind() string {
+	return v.getString(viperAPIBind, defaultBind)
+}
+
+// APIAuthEnabled returns whether API requires authentication.
+// It is enabled by default if any token or user is defined.
+func (v *ViperProvider) APIAuthEnabled() bool {
+	return v.getBool(viperAPIAuthEnabled, len(v.APITokens()) > 0 || len(v.APIUsers()) > 0)
+}
+
+// APIAuthTrustLoopback returns whether requests from the host itself are allowed without authentication
+func (v *ViperProvider) APIAuthTrustLoopback() bool {
+	return v.getBool(viperAPIAuthTrustLoopback, false)
+}
+
+// APIAuthTrustedProxies returns addresses or CIDRs of reverse proxies whose X-Forwarded-For is trusted
+func (v *ViperProvider) APIAuthTrustedProxies() []string {
+	return v.instance().GetStringSlice(viperAPIAuthTrustedProxies)
+}
+
+func (v *ViperProvider) APITokens() []apiauth.Token {
+	var tokens []apiauth.Token
+	if err := v.instance().UnmarshalKey(viperAPIAuthTokens, &tokens); err != nil {
+		return nil
 	}
+	return tokens
+}
+
+func (v *ViperProvider) APIUsers() []apiauth.User {
+	var users []apiauth.User
+	if err := v.instance().UnmarshalKey(viperAPIAuthUsers, &users); err != nil {
+		return nil
+	}
+	return users
+}
 
-	return port
+func (v *ViperProvider) APISessionTTLSec() int {
+	return v.getInt(viperAPIAuthSessionTTLSec, 7*24*60*60)
+}
+
+// APICORSAllowedOrigins returns origins allowed to call API in addition to frontend server
+func (v *ViperProvider) APICORSAllowedOrigins() []string {
+	return v.instance().GetStringSlice(viperAPICORSAllowedOrigins)
+}
+
+// TLSMode returns how servers get certificate. off, files, self-signed or acme.
+func (v *ViperProvider) TLSMode() string {
+	return v.getString(viperTLSMode, tlsconfig.ModeOff)
+}
+
+func (v *ViperProvider) TLSCertFile() string {
+	return v.instance().GetString(viperTLSCertFile)
+}
+
+func (v *ViperProvider) TLSKeyFile() string {
+	return v.instance().GetString(viperTLSKeyFile)
+}
+
+func (v *ViperProvider) TLSSelfSignedDir() string {
+	return v.getString(viperTLSSelfSignedDir, filepath.Join(os.Getenv("HOME"), ".unifi-doorbell-chime", "tls"))
+}
+
+// TLSSelfSignedHosts returns host names and addresses added to self-signed certificate
+// in addition to the ones of this host
+func (v *ViperProvider) TLSSelfSignedHosts() []string {
+	return v.instance().GetStringSlice(viperTLSSelfSignedHosts)
+}
+
+// TLSACMEDirectoryURL returns directory of ACME server. It must be set explicitly
+// so that certificates are not requested to public CA by accident.
+func (v *ViperProvider) TLSACMEDirectoryURL() string {
+	return v.instance().GetString(viperTLSACMEDirectoryURL)
+}
+
+func (v *ViperProvider) TLSACMEEmail() string {
+	return v.instance().GetString(viperTLSACMEEmail)
+}
+
+func (v *ViperProvider) TLSACMEDomains() []string {
+	return v.instance().GetStringSlice(viperTLSACMEDomains)
+}
+
+func (v *ViperProvider) TLSACMECacheDir() string {
+	return v.getString(viperTLSACMECacheDir, filepath.Join(os.Getenv("HOME"), ".unifi-doorbell-chime", "acme"))
+}
+
+// TLSACMEHTTPAddr returns address serving HTTP-01 challenge. Empty serves TLS-ALPN-01 challenge only.
+func (v *ViperProvider) TLSACMEHTTPAddr() string {
+	return v.instance().GetString(viperTLSACMEHTTPAddr)
+}
+
+// TLSHSTSMaxAgeSec returns max-age of Strict-Transport-Security header. Negative disables the header.
+func (v *ViperProvider) TLSHSTSMaxAgeSec() int {
+	return v.getInt(viperTLSHSTSMaxAgeSec, 180*24*60*60)
+}
+
+func (v *ViperProvider) DashboardSnapshotIntervalSec() int {
+	return v.getInt(viperDashboardSnapshotIntervalSec, 10)
+}
+
+func (v *ViperProvider) StreamSource() string {
+	return v.getString(viperStreamSource, "ffmpeg")
+}
+
+func (v *ViperProvider) StreamFFmpegPath() string {
+	return v.getString(viperStreamFFmpegPath, "ffmpeg")
+}
+
+func (v *ViperProvider) StreamFPS() int {
+	return v.getInt(viperStreamFPS, 10)
+}
+
+func (v *ViperProvider) TalkbackEncoder() string {
+	return v.getString(viperTalkbackEncoder, "ffmpeg")
+}
+
+func (v *ViperProvider) TalkbackAddress() string {
+	return v.instance().GetString(viperTalkbackAddress)
+}
+
+func (v *ViperProvider) ClipEnabled() bool {
+	return v.getBool(viperClipEnabled, false)
+}
+
+func (v *ViperProvider) ClipDir() string {
+	return v.getString(viperClipDir, filepath.Join(os.Getenv("HOME"), ".unifi-doorbell-chime", "clips"))
+}
+
+func (v *ViperProvider) ClipMaxDiskUsageMB() int {
+	return v.getInt(viperClipMaxDiskUsageMB, 1024)
+}
+
+func (v *ViperProvider) ClipPreSec() int {
+	return v.getInt(viperClipPreSec, 10)
+}
+
+func (v *ViperProvider) ClipPostSec() int {
+	return v.getInt(viperClipPostSec, 20)
 }
 
 func (v *ViperProvider) MessageList() []string {
-	return viper.GetStringSlice(viperMessageTemplates)
+	return v.instance().GetStringSlice(viperMessageTemplates)
+}
+
+func (v *ViperProvider) ChimeDNDEnabled() bool {
+	return v.getBool(viperChimeDNDEnabled, false)
+}
+
+// default DND hours
+const (
+	defaultChimeDNDStart = "22:00"
+	defaultChimeDNDEnd   = "07:00"
+)
+
+func (v *ViperProvider) ChimeDNDStart() string {
+	return v.getString(viperChimeDNDStart, defaultChimeDNDStart)
+}
+
+func (v *ViperProvider) ChimeDNDEnd() string {
+	return v.getString(viperChimeDNDEnd, defaultChimeDNDEnd)
+}
+
+func (v *ViperProvider) ChimeStateFile() string {
+	return v.getString(viperChimeStateFile, filepath.Join(os.Getenv("HOME"), ".unifi-doorbell-chime", "chime.json"))
+}
+
+func (v *ViperProvider) ControlSocket() string {
+	return v.getString(viperControlSocket, filepath.Join(os.Getenv("HOME"), ".unifi-doorbell-chime", "control.sock"))
+}
+
+// SupervisorRestartPolicy returns restart policy of the service.
+// supervisor.services.<service>.restart overrides supervisor.restart.
+func (v *ViperProvider) SupervisorRestartPolicy(service string) string {
+	if policy := v.instance().GetString(viperSupervisorServices + "." + service + ".restart"); policy != "" {
+		return policy
+	}
+	return v.getString(viperSupervisorRestart, "on-failure")
+}
+
+// SupervisorMaxRestarts returns how many times the service is restarted. 0 is unlimited.
+// supervisor.services.<service>.max_restarts overrides supervisor.max_restarts.
+func (v *ViperProvider) SupervisorMaxRestarts(service string) int {
+	if key := viperSupervisorServices + "." + service + ".max_restarts"; v.instance().IsSet(key) {
+		return v.instance().GetInt(key)
+	}
+	if v.instance().IsSet(viperSupervisorMaxRestarts) {
+		return v.instance().GetInt(viperSupervisorMaxRestarts)
+	}
+	return 10
+}
+
+func (v *ViperProvider) SupervisorBackoffMaxSec() int {
+	return v.getInt(viperSupervisorBackoffMaxSec, 60)
 }
 
 func (v *ViperProvider) BootOptionMacAddress() string {
-	return viper.GetString(viperBootOptionMacAddress)
+	return v.instance().GetString(viperBootOptionMacAddress)
+}
+
+// Networks returns networks which the app runs only on.
+// boot_option.mac_address is regarded as a network identified by MAC address of own interface.
+func (v *ViperProvider) Networks() []netenv.Network {
+	var networks []netenv.Network
+	if err := v.instance().UnmarshalKey(viperNetworkNetworks, &networks); err != nil {
+		networks = nil
+	}
+	if mac := v.BootOptionMacAddress(); mac != "" {
+		networks = append(networks, netenv.Network{
+			Name:         viperBootOptionMacAddress,
+			InterfaceMAC: mac,
+		})
+	}
+	return networks
+}
+
+func (v *ViperProvider) NetworkCheckIntervalSec() int {
+	return v.getInt(viperNetworkCheckIntervalSec, 60)
+}
+
+func (v *ViperProvider) ShutdownTimeoutSec() int {
+	return v.getInt(viperShutdownTimeoutSec, 10)
 }
@@ -1,15 +1,24 @@
 package driver
 
 import (
-	"context"
 	"crypto/tls"
 	"net/http"
 	"time"
 
+	"github.com/sawadashota/unifi-doorbell-chime/chime"
+	"github.com/sawadashota/unifi-doorbell-chime/clip"
+	"github.com/sawadashota/unifi-doorbell-chime/control"
 	"github.com/sawadashota/unifi-doorbell-chime/driver/configuration"
+	"github.com/sawadashota/unifi-doorbell-chime/event"
 	"github.com/sawadashota/unifi-doorbell-chime/listener"
+	"github.com/sawadashota/unifi-doorbell-chime/supervisor"
+	"github.com/sawadashota/unifi-doorbell-chime/web"
 	"github.com/sawadashota/unifi-doorbell-chime/web/api"
 	"github.com/sawadashota/unifi-doorbell-chime/web/frontend"
+	"github.com/sawadashota/unifi-doorbell-chime/x/logbuffer"
+	"github.com/sawadashota/unifi-doorbell-chime/x/stream"
+	"github.com/sawadashota/unifi-doorbell-chime/x/talkback"
+	"github.com/sawadashota/unifi-doorbell-chime/x/tlsconfig"
 	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
 	"github.com/sirupsen/logrus"
 )
@@ -18,28 +27,55 @@ type Registry interface {
 	Logger() logrus.FieldLogger
 	AppLogger(app string) logrus.FieldLogger
 	UnifiClient() *unifi.Client
+	EventHub() *event.Hub
+	StreamProxy() *stream.Proxy
+	Talkback() *talkback.Talkback
+	ClipExporter() *clip.Exporter
+	Chime() *chime.Controller
+	Listener() *listener.Listener
+	LogBuffer() *logbuffer.Buffer
 	Services() []Service
+	WebServices() []Service
+	Supervisor() *supervisor.Supervisor
+	TLS() *tlsconfig.Manager
 }
 
-type Service interface {
-	Start(ctx context.Context) error
-}
+type Service = supervisor.Service
 
 type DefaultRegistry struct {
 	l  logrus.FieldLogger
+	lb *logbuffer.Buffer
 	uc *unifi.Client
+	eh *event.Hub
+	sp *stream.Proxy
+	tb *talkback.Talkback
+	ce *clip.Exporter
+	ch *chime.Controller
 	ls *listener.Listener
 	c  configuration.Provider
+	ws *web.Server
 	fs *frontend.Server
 	as *api.Server
+	cs *control.Server
+	sv *supervisor.Supervisor
+	tm *tlsconfig.Manager
 }
 
 var _ Registry = new(DefaultRegistry)
 
 func NewDefaultRegistry(config configuration.Provider) Registry {
-	return &DefaultRegistry{
+	d := &DefaultRegistry{
 		c: config,
 	}
+	config.Subscribe(d.onConfigReloaded)
+	return d
+}
+
+func (d *DefaultRegistry) onConfigReloaded() {
+	if level, err := logrus.ParseLevel(d.c.LogLevel()); err == nil {
+		d.Logger().(*logrus.Logger).SetLevel(level)
+	}
+	d.Logger().Info("config is reloaded")
 }
 
 func (d *DefaultRegistry) Logger() logrus.FieldLogger {
@@ -55,12 +91,23 @@ func (d *DefaultRegistry) Logger() logrus.FieldLogger {
 			FullTimestamp:   true,
 			TimestampFormat: time.RFC3339,
 		})
+		l.AddHook(d.LogBuffer())
 		d.l = l
 	}
 
 	return d.l
 }
 
+// number of log lines kept for logs command
+const logBufferSize = 1000
+
+func (d *DefaultRegistry) LogBuffer() *logbuffer.Buffer {
+	if d.lb == nil {
+		d.lb = logbuffer.New(logBufferSize)
+	}
+	return d.lb
+}
+
 func (d *DefaultRegistry) AppLogger(app string) logrus.FieldLogger {
 	return d.Logger().(*logrus.Logger).WithField("app", app)
 }
@@ -82,31 +129,104 @@ func (d *DefaultRegistry) UnifiClient() *unifi.Client {
 	return d.uc
 }
 
+func (d *DefaultRegistry) EventHub() *event.Hub {
+	if d.eh == nil {
+		d.eh = event.NewHub(d)
+	}
+	return d.eh
+}
+
+func (d *DefaultRegistry) StreamProxy() *stream.Proxy {
+	if d.sp == nil {
+		d.sp = stream.NewProxy(d, d.c)
+	}
+	return d.sp
+}
+
+func (d *DefaultRegistry) Talkback() *talkback.Talkback {
+	if d.tb == nil {
+		d.tb = talkback.New(d, d.c)
+	}
+	return d.tb
+}
+
+func (d *DefaultRegistry) ClipExporter() *clip.Exporter {
+	if d.ce == nil {
+		d.ce = clip.New(d, d.c)
+	}
+	return d.ce
+}
+
+func (d *DefaultRegistry) Chime() *chime.Controller {
+	if d.ch == nil {
+		d.ch = chime.New(d, d.c)
+	}
+	return d.ch
+}
+
+func (d *DefaultRegistry) Supervisor() *supervisor.Supervisor {
+	if d.sv == nil {
+		d.sv = supervisor.New(d, d.c)
+	}
+	return d.sv
+}
+
 func (d *DefaultRegistry) Services() []Service {
+	return append([]Service{
+		d.Listener(),
+		d.ClipExporter(),
+		d.Chime(),
+		d.controlServer(),
+	}, d.WebServices()...)
+}
+
+// WebServices returns servers of API and ringing page
+func (d *DefaultRegistry) WebServices() []Service {
 	return []Service{
-		d.listener(),
-		d.webApiServer(),
-		d.webFrontendServer(),
+		d.webServer(),
+		d.WebAPIServer(),
+		d.TLS(),
 	}
 }
 
-func (d *DefaultRegistry) listener() *listener.Listener {
+func (d *DefaultRegistry) TLS() *tlsconfig.Manager {
+	if d.tm == nil {
+		d.tm = tlsconfig.New(d, d.c)
+	}
+	return d.tm
+}
+
+func (d *DefaultRegistry) Listener() *listener.Listener {
 	if d.ls == nil {
 		d.ls = listener.New(d, d.c)
 	}
 	return d.ls
 }
 
-func (d *DefaultRegistry) webFrontendServer() *frontend.Server {
+func (d *DefaultRegistry) webServer() *web.Server {
+	if d.ws == nil {
+		d.ws = web.New(d, d.c)
+	}
+	return d.ws
+}
+
+func (d *DefaultRegistry) WebFrontendServer() *frontend.Server {
 	if d.fs == nil {
 		d.fs = frontend.New(d, d.c)
 	}
 	return d.fs
 }
 
-func (d *DefaultRegistry) webApiServer() *api.Server {
+func (d *DefaultRegistry) WebAPIServer() *api.Server {
 	if d.as == nil {
 		d.as = api.New(d, d.c)
 	}
 	return d.as
 }
+
+func (d *DefaultRegistry) controlServer() *control.Server {
+	if d.cs == nil {
+		d.cs = control.New(d, d.c)
+	}
+	return d.cs
+}
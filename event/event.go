@@ -0,0 +1,64 @@
+package event
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeRing    Type = "ring"
+	TypeMotion  Type = "motion"
+	TypeHealth  Type = "health"
+	TypeMessage Type = "message"
+	TypePackage Type = "package"
+)
+
+type HealthStatus string
+
+const (
+	HealthStatusUp   HealthStatus = "up"
+	HealthStatusDown HealthStatus = "down"
+)
+
+// PackageStatus is what happened to package detected by package camera of doorbell
+type PackageStatus string
+
+const (
+	PackageDelivered PackageStatus = "delivered"
+	PackagePickedUp  PackageStatus = "picked_up"
+)
+
+type Event struct {
+	ID           string        `json:"id"`
+	Type         Type          `json:"type"`
+	Time         time.Time     `json:"time"`
+	DoorbellID   string        `json:"doorbell_id,omitempty"`
+	DoorbellName string        `json:"doorbell_name,omitempty"`
+	Message      string        `json:"message,omitempty"`
+	Package      PackageStatus `json:"package,omitempty"`
+	Status       HealthStatus  `json:"status,omitempty"`
+	Error        string        `json:"error,omitempty"`
+	// Synthetic is true if the event is injected for testing
+	Synthetic bool `json:"synthetic,omitempty"`
+}
+
+func New(t Type) Event {
+	return Event{
+		ID:   newID(),
+		Type: t,
+		Time: time.Now(),
+	}
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		// hex like random ID so that it is valid as file name of clip
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
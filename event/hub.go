@@ -0,0 +1,61 @@
+package event
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const subscriberBufferSize = 16
+
+type Hub struct {
+	mu     sync.RWMutex
+	subs   map[chan Event]struct{}
+	logger logrus.FieldLogger
+}
+
+type Registry interface {
+	AppLogger(app string) logrus.FieldLogger
+}
+
+func NewHub(r Registry) *Hub {
+	return &Hub{
+		subs:   make(map[chan Event]struct{}),
+		logger: r.AppLogger("event"),
+	}
+}
+
+// Publish delivers e to every subscriber.
+// Slow subscribers never block the publisher; events are dropped for them instead.
+func (h *Hub) Publish(e Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	h.logger.Debugf("publish %s event %s", e.Type, e.ID)
+	for ch := range h.subs {
+		select {
+		case ch <- e:
+		default:
+			h.logger.Warnf("subscriber is too slow. %s event %s is dropped", e.Type, e.ID)
+		}
+	}
+}
+
+// Subscribe returns channel receiving published events and function to stop subscription.
+func (h *Hub) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBufferSize)
+
+	h.mu.Lock()
+	h.subs[ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subs, ch)
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+}
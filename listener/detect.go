@@ -0,0 +1,62 @@
+package listener
+
+import (
+	"time"
+
+	"github.com/sawadashota/unifi-doorbell-chime/event"
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+)
+
+// Detect returns events happened between previous and current states of doorbells
+func Detect(previous, current unifi.Doorbells) []event.Event {
+	return DetectChanges(unifi.Diff(cameras(previous), cameras(current)))
+}
+
+// DetectChanges returns events of doorbells which happened according to their changes
+func DetectChanges(changes []unifi.Change) []event.Event {
+	var events []event.Event
+	for _, c := range changes {
+		if c.Added() || c.Removed() {
+			continue
+		}
+		d := unifi.Doorbell(*c.Current)
+		if c.Changed("lastRing") && c.Current.LastRing > c.Previous.LastRing {
+			events = append(events, NewDoorbellEvent(event.TypeRing, &d))
+		}
+		if c.Changed("lastMotion") && c.Current.LastMotion > c.Previous.LastMotion {
+			events = append(events, NewDoorbellEvent(event.TypeMotion, &d))
+		}
+		if c.Changed("lcdMessage.type", "lcdMessage.text") {
+			events = append(events, NewDoorbellEvent(event.TypeMessage, &d))
+		}
+	}
+	return events
+}
+
+func cameras(ds unifi.Doorbells) unifi.Cameras {
+	cs := make(unifi.Cameras, 0, len(ds))
+	for _, d := range ds {
+		cs = append(cs, unifi.Camera(d))
+	}
+	return cs
+}
+
+// NewDoorbellEvent returns event of the doorbell which happened at the time recorded by UniFi Protect
+func NewDoorbellEvent(t event.Type, doorbell *unifi.Doorbell) event.Event {
+	e := event.New(t)
+	e.DoorbellID = doorbell.ID
+	e.DoorbellName = doorbell.Name
+	switch t {
+	case event.TypeRing:
+		if doorbell.LastRing > 0 {
+			e.Time = time.Unix(0, int64(doorbell.LastRing)*int64(time.Millisecond))
+		}
+	case event.TypeMotion:
+		if doorbell.LastMotion > 0 {
+			e.Time = time.Unix(0, doorbell.LastMotion*int64(time.Millisecond))
+		}
+	case event.TypeMessage:
+		e.Message = doorbell.LcdMessage.Text
+	}
+	return e
+}
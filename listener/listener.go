@@ -2,69 +2,136 @@ package listener
 
 import (
 	"context"
-	"fmt"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
-	"github.com/pkg/browser"
-	"github.com/pkg/errors"
+	"github.com/sawadashota/unifi-doorbell-chime/event"
+	"github.com/sawadashota/unifi-doorbell-chime/supervisor"
+	"github.com/sawadashota/unifi-doorbell-chime/x/tlsconfig"
 	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/xerrors"
 )
 
 type Listener struct {
-	state  unifi.Doorbells
-	r      Registry
-	c      Configuration
-	logger logrus.FieldLogger
+	mu            sync.RWMutex
+	state         unifi.Doorbells
+	paused        bool
+	authenticated bool
+	lastPoll      time.Time
+	lastError     string
+	health        event.HealthStatus
+
+	r         Registry
+	c         Configuration
+	logger    logrus.FieldLogger
+	events    *event.Hub
+	notifiers []Notifier
+	reloaded  chan struct{}
+	packages  *packageTracker
 }
 
 type Registry interface {
 	AppLogger(app string) logrus.FieldLogger
 	UnifiClient() *unifi.Client
+	EventHub() *event.Hub
+	TLS() *tlsconfig.Manager
 }
 
 type Configuration interface {
 	WebPort() int
+	WebBasePath() string
+	Subscribe(fn func())
 }
 
 func New(r Registry, c Configuration) *Listener {
-	return &Listener{
+	l := &Listener{
 		r:      r,
 		c:      c,
 		logger: r.AppLogger("listener"),
+		events: r.EventHub(),
+		notifiers: []Notifier{
+			&browserNotifier{c: c, tls: r.TLS()},
+		},
+		reloaded: make(chan struct{}, 1),
+		packages: newPackageTracker(),
+	}
+	c.Subscribe(l.onConfigReloaded)
+	return l
+}
+
+func (l *Listener) onConfigReloaded() {
+	select {
+	case l.reloaded <- struct{}{}:
+	default:
 	}
 }
 
+// poll refreshes bootstrap cache. Changes of doorbells are delivered to onChanges.
 func (l *Listener) poll(ctx context.Context) error {
-	ds, err := l.r.UnifiClient().GetDoorbells(ctx)
+	b, err := l.r.UnifiClient().Cache().Refresh(ctx)
 	if err != nil {
+		l.setPollResult(nil, err)
 		return xerrors.Errorf("failed to poll: %w", err)
 	}
 
-	for _, d := range ds {
-		if d.DoesRung(l.state) {
-			if err := l.onRung(&d); err != nil {
-				return errors.WithStack(err)
-			}
+	ds := l.r.UnifiClient().FilterDoorbells(b)
+	l.setPollResult(ds, nil)
+	return nil
+}
+
+// onChanges publishes events of watched doorbells and notifies rings
+func (l *Listener) onChanges(ctx context.Context, changes []unifi.Change) {
+	var watched []unifi.Change
+	for _, c := range changes {
+		if c.Current != nil && l.r.UnifiClient().IsWatchedDoorbell(*c.Current) {
+			watched = append(watched, c)
 		}
 	}
 
-	l.state = ds
-	return nil
+	for _, e := range DetectChanges(watched) {
+		if e.Type == event.TypeRing {
+			if err := l.onRung(ctx, e); err != nil {
+				l.logger.Error(err)
+			}
+			continue
+		}
+		l.events.Publish(e)
+	}
 }
 
 const pollingInterval = 1 * time.Second
 
+// reconnectTimeout is how long listener keeps connecting to unavailable UniFi Protect before giving up
+const reconnectTimeout = 30 * time.Minute
+
+// ErrUnreachable is returned when UniFi Protect is unavailable longer than reconnectTimeout
+var ErrUnreachable = xerrors.New("UniFi Protect is unreachable")
+
 func (l *Listener) Start(ctx context.Context) error {
 	defer l.logger.Info("Bye!")
 
+	// changes by refreshes of API and others are detected as well
+	changes, unsubscribe := l.r.UnifiClient().Cache().Subscribe()
+	defer unsubscribe()
+
 	if err := l.ping(ctx); err != nil {
-		l.logger.Error(err)
-		return errors.WithStack(err)
+		return xerrors.Errorf("failed to connect UniFi Protect: %w", err)
 	}
 
+	// searching detections of package cameras doesn't delay polling for rings
+	packagesCtx, cancel := context.WithCancel(ctx)
+	packagesDone := make(chan struct{})
+	go func() {
+		defer close(packagesDone)
+		l.watchPackages(packagesCtx)
+	}()
+	defer func() {
+		cancel()
+		<-packagesDone
+	}()
+
 	ticker := time.NewTicker(pollingInterval)
 	defer ticker.Stop()
 
@@ -73,46 +140,125 @@ func (l *Listener) Start(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 
+		case cs := <-changes:
+			l.onChanges(ctx, cs)
+
+		case <-l.reloaded:
+			// UniFi credentials may be changed
+			l.logger.Info("re authenticate because config is reloaded")
+			if err := l.ping(ctx); err != nil {
+				return xerrors.Errorf("failed to connect UniFi Protect: %w", err)
+			}
+
 		case <-ticker.C:
 			if err := l.poll(ctx); err != nil {
-				l.logger.Debugf("%+v", err)
-				return xerrors.Errorf(": %w", err)
+				if ctx.Err() != nil {
+					return nil
+				}
+				// keep listening after network or controller recovers
+				l.logger.Warnf("failed to poll. reconnecting: %s", err)
+				l.publishHealth(err)
+				if err := l.ping(ctx); err != nil {
+					return xerrors.Errorf("failed to connect UniFi Protect: %w", err)
+				}
 			}
 		}
 	}
 }
+
+// ping authenticates and gets doorbells with retry until it succeeds, ctx is done or reconnectTimeout passes
 func (l *Listener) ping(ctx context.Context) error {
 	bc := backoff.NewExponentialBackOff()
-	bc.MaxElapsedTime = time.Minute * 5
+	bc.MaxElapsedTime = reconnectTimeout
 	bc.Reset()
 
-	return backoff.Retry(func() error {
+	err := backoff.Retry(func() error {
 		if err := l.r.UnifiClient().Authenticate(); err != nil {
+			l.setAuthenticated(false)
+			l.setPollResult(nil, err)
 			l.logger.Error(err)
+			l.publishHealth(err)
+			// retrying never fixes wrong credentials
+			if xerrors.Is(err, unifi.ErrUnauthorized) {
+				return backoff.Permanent(err)
+			}
 			return xerrors.Errorf("failed to authenticate: %w", err)
 		}
 
-		doorbells, err := l.r.UnifiClient().GetDoorbells(ctx)
+		b, err := l.r.UnifiClient().Cache().Refresh(ctx)
 		if err != nil {
+			l.setPollResult(nil, err)
 			l.logger.Error(err)
+			l.publishHealth(err)
 			return xerrors.Errorf("failed to start listener: %w", err)
 		}
+		l.setPollResult(l.r.UnifiClient().FilterDoorbells(b), nil)
 
-		for _, d := range doorbells {
+		for _, d := range l.r.UnifiClient().FilterDoorbells(b) {
 			l.logger.Infof("activate %s ID: %s\n", d.Name, d.ID)
 		}
+		l.setAuthenticated(true)
+		l.publishHealth(nil)
+		return nil
+	}, backoff.WithContext(bc, ctx))
+	if ctx.Err() != nil {
 		return nil
-	}, bc)
+	}
+	if xerrors.Is(err, unifi.ErrUnauthorized) {
+		// restarting listener never fixes wrong credentials either
+		return supervisor.Permanent(err)
+	}
+	if err != nil {
+		// leave restarting to service manager rather than retrying forever
+		return supervisor.Permanent(xerrors.Errorf("gave up connecting for %s: %s: %w", reconnectTimeout, err, ErrUnreachable))
+	}
+	return nil
 }
 
-func (l *Listener) onRung(doorbell *unifi.Doorbell) error {
-	err := browser.OpenURL(
-		fmt.Sprintf("http://127.0.0.1:%d/ringing/%s", l.c.WebPort(), doorbell.ID),
-	)
-	if err != nil {
-		return xerrors.Errorf("failed to open browser: %w", err)
+func (l *Listener) onRung(ctx context.Context, e event.Event) error {
+	for _, r := range l.notify(ctx, e) {
+		if !r.OK {
+			return xerrors.Errorf("failed to notify by %s: %s", r.Name, r.Error)
+		}
 	}
 
-	l.logger.Infof("%s (%s) is rung!\n", doorbell.Name, doorbell.Mac)
+	l.logger.Infof("%s (%s) is rung!\n", e.DoorbellName, e.DoorbellID)
 	return nil
 }
+
+// notify delivers event to subscribers and every notifier
+func (l *Listener) notify(ctx context.Context, e event.Event) []StepResult {
+	l.events.Publish(e)
+	results := []StepResult{newStepResult("event", nil)}
+
+	if l.isPaused() {
+		for _, n := range l.notifiers {
+			results = append(results, StepResult{Name: n.Name(), OK: true, Skipped: true})
+		}
+		return results
+	}
+
+	for _, n := range l.notifiers {
+		results = append(results, newStepResult(n.Name(), n.Notify(ctx, e)))
+	}
+	return results
+}
+
+// publishHealth publishes health event only when health is changed
+func (l *Listener) publishHealth(err error) {
+	e := event.New(event.TypeHealth)
+	e.Status = event.HealthStatusUp
+	if err != nil {
+		e.Status = event.HealthStatusDown
+		e.Error = err.Error()
+	}
+
+	l.mu.Lock()
+	changed := l.health != e.Status
+	l.health = e.Status
+	l.mu.Unlock()
+
+	if changed {
+		l.events.Publish(e)
+	}
+}
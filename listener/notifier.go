@@ -0,0 +1,42 @@
+package listener
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/browser"
+	"github.com/sawadashota/unifi-doorbell-chime/event"
+	"github.com/sawadashota/unifi-doorbell-chime/x/tlsconfig"
+	"golang.org/x/xerrors"
+)
+
+// Notifier notifies user of ring and package
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, e event.Event) error
+}
+
+// browserNotifier opens ringing page on default browser, or dashboard showing package snapshot for package
+type browserNotifier struct {
+	c   Configuration
+	tls *tlsconfig.Manager
+}
+
+func (n *browserNotifier) Name() string {
+	return "browser"
+}
+
+func (n *browserNotifier) Notify(_ context.Context, e event.Event) error {
+	page := "/ringing/" + e.DoorbellID
+	if e.Type == event.TypePackage {
+		page = "/dashboard"
+	}
+
+	err := browser.OpenURL(
+		fmt.Sprintf("%s%s%s", n.tls.LocalURL(n.c.WebPort()), n.c.WebBasePath(), page),
+	)
+	if err != nil {
+		return xerrors.Errorf("failed to open browser: %w", err)
+	}
+	return nil
+}
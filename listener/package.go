@@ -0,0 +1,221 @@
+package listener
+
+import (
+	"context"
+	"time"
+
+	"github.com/sawadashota/unifi-doorbell-chime/event"
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+)
+
+const (
+	// packageCheckInterval is interval of searching detections of package cameras.
+	// It is longer than pollingInterval not to add requests to UniFi Protect on every poll.
+	packageCheckInterval = 10 * time.Second
+	// packageMissedWindows is number of checks without package detection to consider the package picked up
+	packageMissedWindows = 3
+	// maxPackagePresence is how long package is tracked after delivery.
+	// Detection which never ends is given up after this.
+	maxPackagePresence = 24 * time.Hour
+)
+
+// packageState is package detection of a doorbell.
+// Times are milliseconds since epoch like UniFi Protect.
+type packageState struct {
+	// checked is end of period which has been searched
+	checked int64
+	// present is true while package is considered to be there
+	present bool
+	// delivered is when package was detected first
+	delivered int64
+	// lastSeen is when package was detected last. It is end of the period if detection is in progress.
+	lastSeen int64
+	// visitor is start of another detection over the zone after package was seen last, or 0
+	visitor int64
+	// missed is number of checks without package detection after it was seen last
+	missed int
+	// active is start of the earliest package detection in progress, or 0
+	active int64
+}
+
+// packageTracker follows packages detected by package cameras of doorbells.
+// Package is delivered when UniFi Protect starts detecting it and picked up
+// when it is no longer detected in following checks. Someone else detected over the zone
+// after the package means it is picked up as soon as the package is not detected.
+// It is used only by the goroutine of Listener.watchPackages.
+type packageTracker struct {
+	states map[string]*packageState
+}
+
+func newPackageTracker() *packageTracker {
+	return &packageTracker{
+		states: make(map[string]*packageState),
+	}
+}
+
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func fromMillis(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+// since returns start of period to search detections of the doorbell until now,
+// or false on the first check which only starts tracking.
+// It isn't skipped by lastSmartDetect of the doorbell, which is of the main camera and not of the package camera.
+func (t *packageTracker) since(d unifi.Doorbell, now time.Time) (time.Time, bool) {
+	s, ok := t.states[d.ID]
+	if !ok {
+		// packages detected before start are not notified
+		t.states[d.ID] = &packageState{checked: toMillis(now)}
+		return time.Time{}, false
+	}
+	// detection in progress is searched from its start in case UniFi Protect searches detections by start
+	if s.active > 0 && s.active < s.checked {
+		return fromMillis(s.active), true
+	}
+	return fromMillis(s.checked), true
+}
+
+// update returns package events of the doorbell according to detections between since and now
+func (t *packageTracker) update(d unifi.Doorbell, detections []unifi.SmartDetectEvent, now time.Time) []event.Event {
+	s := t.states[d.ID]
+	from, to := s.checked, toMillis(now)
+	s.checked = to
+
+	var (
+		seen     bool
+		visitor  int64
+		arrived  int64
+		active   int64
+		lastSeen = s.lastSeen
+	)
+	for _, det := range detections {
+		end := to
+		if det.End != nil && *det.End < to {
+			end = *det.End
+		}
+		if !det.Detects(unifi.SmartDetectTypePackage) {
+			if det.Start > s.lastSeen && (visitor == 0 || det.Start < visitor) {
+				visitor = det.Start
+			}
+			continue
+		}
+
+		seen = true
+		if end > lastSeen {
+			lastSeen = end
+		}
+		if det.End == nil && (active == 0 || det.Start < active) {
+			active = det.Start
+		}
+		// detections started before this period were there on previous check
+		if det.Start >= from && (arrived == 0 || det.Start < arrived) {
+			arrived = det.Start
+		}
+	}
+
+	s.active = active
+	if seen {
+		var events []event.Event
+		if !s.present && arrived > 0 {
+			s.present = true
+			s.delivered = arrived
+			events = append(events, newPackageEvent(d, event.PackageDelivered, arrived))
+		}
+		s.lastSeen = lastSeen
+		s.visitor = 0
+		s.missed = 0
+		return events
+	}
+	if !s.present {
+		return nil
+	}
+
+	if visitor > 0 && (s.visitor == 0 || visitor < s.visitor) {
+		s.visitor = visitor
+	}
+	s.missed++
+	if s.missed < packageMissedWindows && s.visitor == 0 {
+		return nil
+	}
+
+	at := s.lastSeen
+	if s.visitor > 0 {
+		at = s.visitor
+	}
+	*s = packageState{checked: s.checked}
+	return []event.Event{newPackageEvent(d, event.PackagePickedUp, at)}
+}
+
+// expire stops tracking package of the doorbell delivered before max presence and reports whether it did
+func (t *packageTracker) expire(d unifi.Doorbell, now time.Time) bool {
+	s, ok := t.states[d.ID]
+	if !ok || !s.present || now.Sub(fromMillis(s.delivered)) < maxPackagePresence {
+		return false
+	}
+	*s = packageState{checked: s.checked}
+	return true
+}
+
+func newPackageEvent(d unifi.Doorbell, status event.PackageStatus, at int64) event.Event {
+	e := event.New(event.TypePackage)
+	e.DoorbellID = d.ID
+	e.DoorbellName = d.Name
+	e.Package = status
+	if at > 0 {
+		e.Time = fromMillis(at)
+	}
+	return e
+}
+
+// watchPackages notifies packages delivered to or picked up from doorbells with package camera
+// every packageCheckInterval until ctx is done
+func (l *Listener) watchPackages(ctx context.Context) {
+	ticker := time.NewTicker(packageCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.checkPackages(ctx, l.doorbells())
+		}
+	}
+}
+
+func (l *Listener) checkPackages(ctx context.Context, ds unifi.Doorbells) {
+	for _, d := range ds {
+		if !d.HasPackageCamera() {
+			continue
+		}
+		now := time.Now()
+		if l.packages.expire(d, now) {
+			l.logger.Warnf("package at %s is detected over %s. stop tracking it", d.Name, maxPackagePresence)
+		}
+		since, ok := l.packages.since(d, now)
+		if !ok {
+			continue
+		}
+
+		detections, err := l.r.UnifiClient().GetSmartDetectEvents(ctx, d.ID, since, now)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			// retried on next check because searched period is not updated
+			l.logger.Warnf("failed to check packages of %s: %s", d.Name, err)
+			continue
+		}
+		for _, e := range l.packages.update(d, detections, now) {
+			for _, r := range l.notify(ctx, e) {
+				if !r.OK {
+					l.logger.Errorf("failed to notify package of %s by %s: %s", d.Name, r.Name, r.Error)
+				}
+			}
+			l.logger.Infof("package is %s at %s (%s)", e.Package, d.Name, d.ID)
+		}
+	}
+}
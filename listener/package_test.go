@@ -0,0 +1,166 @@
+package listener
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sawadashota/unifi-doorbell-chime/event"
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+)
+
+// packageTest drives packageTracker like Listener.checkPackages every packageCheckInterval
+type packageTest struct {
+	t       *testing.T
+	tracker *packageTracker
+	door    unifi.Doorbell
+	now     time.Time
+}
+
+func newPackageTest(t *testing.T) *packageTest {
+	p := &packageTest{
+		t:       t,
+		tracker: newPackageTracker(),
+		door:    unifi.Doorbell{ID: "porch", Name: "Porch"},
+		now:     time.Unix(1600000000, 0),
+	}
+	if _, ok := p.tracker.since(p.door, p.now); ok {
+		t.Fatal("first check must not search detections")
+	}
+	return p
+}
+
+// at returns milliseconds of d after current time
+func (p *packageTest) at(d time.Duration) int64 {
+	return toMillis(p.now.Add(d))
+}
+
+// check advances time and returns events by detections, or nil if nothing is searched.
+// Detections started before the searched period are not given like UniFi Protect searching them by start.
+// lastSmartDetect of the doorbell is left as it is because it is of the main camera.
+func (p *packageTest) check(detections ...unifi.SmartDetectEvent) []event.Event {
+	p.t.Helper()
+	p.now = p.now.Add(packageCheckInterval)
+	since, ok := p.tracker.since(p.door, p.now)
+	if !ok {
+		return nil
+	}
+	var searched []unifi.SmartDetectEvent
+	for _, det := range detections {
+		if det.Start >= toMillis(since) {
+			searched = append(searched, det)
+		}
+	}
+	return p.tracker.update(p.door, searched, p.now)
+}
+
+func (p *packageTest) expect(events []event.Event, status event.PackageStatus, at int64) {
+	p.t.Helper()
+	if len(events) != 1 {
+		p.t.Fatalf("got %d events, want %s", len(events), status)
+	}
+	if events[0].Package != status {
+		p.t.Errorf("got %s, want %s", events[0].Package, status)
+	}
+	if got := toMillis(events[0].Time); got != at {
+		p.t.Errorf("%s at %d, want %d", status, got, at)
+	}
+}
+
+func detection(id string, start int64, end *int64, types ...string) unifi.SmartDetectEvent {
+	return unifi.SmartDetectEvent{ID: id, Type: "smartDetectZone", Camera: "porch", Start: start, End: end, SmartDetectTypes: types}
+}
+
+func TestPackageIsNotPickedUpWhenDetectionEnds(t *testing.T) {
+	p := newPackageTest(t)
+
+	delivered := p.at(3 * time.Second)
+	p.expect(p.check(detection("1", delivered, nil, unifi.SmartDetectTypePackage)), event.PackageDelivered, delivered)
+
+	end := p.at(2 * time.Second)
+	ended := detection("1", delivered, &end, unifi.SmartDetectTypePackage)
+	if events := p.check(ended); len(events) != 0 {
+		t.Fatalf("package is %s when detection ends", events[0].Package)
+	}
+	for i := 1; i < packageMissedWindows; i++ {
+		if events := p.check(); len(events) != 0 {
+			t.Fatalf("package is %s after %d checks without detection", events[0].Package, i)
+		}
+	}
+	p.expect(p.check(), event.PackagePickedUp, end)
+}
+
+func TestPackageIsPickedUpAfterVisitor(t *testing.T) {
+	p := newPackageTest(t)
+
+	delivered := p.at(time.Second)
+	end := p.at(2 * time.Second)
+	p.expect(p.check(detection("1", delivered, &end, unifi.SmartDetectTypePackage)), event.PackageDelivered, delivered)
+
+	visitor := p.at(5 * time.Second)
+	p.expect(p.check(detection("2", visitor, nil, "person")), event.PackagePickedUp, visitor)
+}
+
+func TestPackageIsKeptWhileDetected(t *testing.T) {
+	p := newPackageTest(t)
+
+	delivered := p.at(time.Second)
+	p.expect(p.check(detection("1", delivered, nil, unifi.SmartDetectTypePackage)), event.PackageDelivered, delivered)
+
+	// deliverer leaves while package is detected
+	visitor := detection("2", p.at(2*time.Second), nil, "person")
+	package1 := detection("1", delivered, nil, unifi.SmartDetectTypePackage)
+	for i := 0; i < packageMissedWindows*2; i++ {
+		if events := p.check(visitor, package1); len(events) != 0 {
+			t.Fatalf("package is %s while detected", events[0].Package)
+		}
+	}
+
+	// another detection of the same package isn't delivery
+	if events := p.check(detection("3", p.at(time.Second), nil, unifi.SmartDetectTypePackage)); len(events) != 0 {
+		t.Fatalf("package is %s again", events[0].Package)
+	}
+}
+
+func TestPackageExpires(t *testing.T) {
+	p := newPackageTest(t)
+
+	delivered := p.at(time.Second)
+	open := detection("1", delivered, nil, unifi.SmartDetectTypePackage)
+	p.expect(p.check(open), event.PackageDelivered, delivered)
+
+	p.now = p.now.Add(maxPackagePresence)
+	if !p.tracker.expire(p.door, p.now) {
+		t.Fatal("package detected over max presence is not expired")
+	}
+
+	// detection which never ends is not delivered again
+	visitor := detection("2", p.at(time.Second), nil, "person")
+	if events := p.check(open, visitor); len(events) != 0 {
+		t.Fatalf("package is %s after expired", events[0].Package)
+	}
+}
+
+func TestPackageInProgressIsSearchedFromItsStart(t *testing.T) {
+	p := newPackageTest(t)
+
+	delivered := p.at(time.Second)
+	open := detection("1", delivered, nil, unifi.SmartDetectTypePackage)
+	p.expect(p.check(open), event.PackageDelivered, delivered)
+
+	for i := 0; i < packageMissedWindows*2; i++ {
+		since, _ := p.tracker.since(p.door, p.now)
+		if toMillis(since) > delivered {
+			t.Fatalf("detection in progress is not searched: since %d, started %d", toMillis(since), delivered)
+		}
+		if events := p.check(open); len(events) != 0 {
+			t.Fatalf("package is %s while detection is in progress", events[0].Package)
+		}
+	}
+
+	// searched from end of previous check once detection ends
+	end := p.at(time.Second)
+	p.check(detection("1", delivered, &end, unifi.SmartDetectTypePackage))
+	if since, _ := p.tracker.since(p.door, p.now); !since.Equal(p.now) {
+		t.Errorf("ended detection is searched again from %s", since)
+	}
+}
@@ -0,0 +1,87 @@
+package listener
+
+import (
+	"context"
+	"io"
+
+	"github.com/sawadashota/unifi-doorbell-chime/event"
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"golang.org/x/xerrors"
+)
+
+// StepResult is result of a step of ring pipeline
+type StepResult struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+	// Skipped is true if notification is paused
+	Skipped bool `json:"skipped,omitempty"`
+}
+
+func newStepResult(name string, err error) StepResult {
+	r := StepResult{
+		Name: name,
+		OK:   err == nil,
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// RingReport is summary of simulated ring
+type RingReport struct {
+	Event event.Event  `json:"event"`
+	Steps []StepResult `json:"steps"`
+}
+
+// OK reports whether every step succeeded
+func (r *RingReport) OK() bool {
+	for _, s := range r.Steps {
+		if !s.OK {
+			return false
+		}
+	}
+	return true
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+var _ io.Writer = new(countingWriter)
+
+// SimulateRing injects synthetic ring of the doorbell into ring pipeline
+// and reports whether snapshot capture, event delivery and every notifier succeeded.
+func (l *Listener) SimulateRing(ctx context.Context, doorbellID string) (*RingReport, error) {
+	d, err := l.r.UnifiClient().Cache().Doorbell(ctx, doorbellID, unifi.DefaultMaxAge)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to simulate ring: %w", err)
+	}
+
+	e := event.New(event.TypeRing)
+	e.DoorbellID = d.ID
+	e.DoorbellName = d.Name
+	e.Synthetic = true
+
+	report := &RingReport{
+		Event: e,
+	}
+
+	var w countingWriter
+	err = l.r.UnifiClient().GetSnapshot(ctx, &w, d.ID)
+	if err == nil && w.n == 0 {
+		err = xerrors.New("snapshot is empty")
+	}
+	report.Steps = append(report.Steps, newStepResult("snapshot", err))
+
+	report.Steps = append(report.Steps, l.notify(ctx, e)...)
+
+	l.logger.Infof("simulated ring of %s (%s)", d.Name, d.ID)
+	return report, nil
+}
@@ -0,0 +1,99 @@
+package listener
+
+import (
+	"time"
+
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+)
+
+// DoorbellStatus is status of a watched doorbell
+type DoorbellStatus struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	IsConnected bool   `json:"is_connected"`
+	LastRing    int64  `json:"last_ring,omitempty"`
+}
+
+// Status is current state of listener
+type Status struct {
+	Authenticated bool             `json:"authenticated"`
+	Paused        bool             `json:"paused"`
+	LastPoll      time.Time        `json:"last_poll,omitempty"`
+	LastError     string           `json:"last_error,omitempty"`
+	Doorbells     []DoorbellStatus `json:"doorbells"`
+}
+
+// Status returns current state of listener
+func (l *Listener) Status() Status {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	s := Status{
+		Authenticated: l.authenticated,
+		Paused:        l.paused,
+		LastPoll:      l.lastPoll,
+		LastError:     l.lastError,
+		Doorbells:     make([]DoorbellStatus, 0, len(l.state)),
+	}
+	for _, d := range l.state {
+		s.Doorbells = append(s.Doorbells, DoorbellStatus{
+			ID:          d.ID,
+			Name:        d.Name,
+			IsConnected: d.IsConnected,
+			LastRing:    int64(d.LastRing),
+		})
+	}
+	return s
+}
+
+// Pause stops notifying rings. Events are still published.
+func (l *Listener) Pause() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.paused {
+		l.logger.Info("notification is paused")
+	}
+	l.paused = true
+}
+
+// Resume restarts notifying rings
+func (l *Listener) Resume() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.paused {
+		l.logger.Info("notification is resumed")
+	}
+	l.paused = false
+}
+
+func (l *Listener) isPaused() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.paused
+}
+
+func (l *Listener) setAuthenticated(authenticated bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.authenticated = authenticated
+}
+
+func (l *Listener) setPollResult(ds unifi.Doorbells, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastPoll = time.Now()
+	if err != nil {
+		l.lastError = err.Error()
+		return
+	}
+	l.lastError = ""
+	l.state = ds
+}
+
+// doorbells returns watched doorbells of last successful poll
+func (l *Listener) doorbells() unifi.Doorbells {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.state
+}
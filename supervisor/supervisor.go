@@ -0,0 +1,244 @@
+package supervisor
+
+import (
+	"context"
+	"path"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// Service is long running process supervised by Supervisor
+type Service interface {
+	Start(ctx context.Context) error
+}
+
+// Policy decides whether stopped service is restarted
+type Policy string
+
+const (
+	// PolicyAlways restarts service even if it stops without error
+	PolicyAlways Policy = "always"
+	// PolicyOnFailure restarts service only if it stops with error
+	PolicyOnFailure Policy = "on-failure"
+	// PolicyNever never restarts service
+	PolicyNever Policy = "never"
+)
+
+// State is health state of service
+type State string
+
+const (
+	StateRunning State = "running"
+	// StateBackoff is waiting to restart service
+	StateBackoff State = "backoff"
+	// StateFailed is given up restarting service
+	StateFailed  State = "failed"
+	StateStopped State = "stopped"
+)
+
+// Status is health of service
+type Status struct {
+	Name     string    `json:"name"`
+	State    State     `json:"state"`
+	Restarts int       `json:"restarts"`
+	Since    time.Time `json:"since"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// service running longer than this is regarded as recovered and its restart count is reset
+const stableDuration = 5 * time.Minute
+
+// Permanent wraps err so that the service stopped by it is never restarted,
+// e.g. UniFi Protect rejected credentials and retrying never fixes it
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+type Registry interface {
+	AppLogger(app string) logrus.FieldLogger
+}
+
+type Configuration interface {
+	SupervisorRestartPolicy(service string) string
+	SupervisorMaxRestarts(service string) int
+	SupervisorBackoffMaxSec() int
+}
+
+// Supervisor starts services and restarts them according to restart policy
+type Supervisor struct {
+	c      Configuration
+	logger logrus.FieldLogger
+
+	// stableDuration and sleep are replaced by tests
+	stableDuration time.Duration
+	sleep          func(ctx context.Context, d time.Duration) bool
+
+	mu       sync.RWMutex
+	names    []string
+	statuses map[string]Status
+}
+
+func New(r Registry, c Configuration) *Supervisor {
+	return &Supervisor{
+		c:              c,
+		logger:         r.AppLogger("supervisor"),
+		stableDuration: stableDuration,
+		sleep:          sleep,
+		statuses:       make(map[string]Status),
+	}
+}
+
+// sleep waits for d and returns false if ctx is done meanwhile
+func sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// Name returns name of service which is its package name
+func Name(s Service) string {
+	t := reflect.TypeOf(s)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return path.Base(t.PkgPath())
+}
+
+// Run supervises services until ctx is done.
+// When a service fails and is not restarted anymore, every service is stopped and its error is returned.
+func (s *Supervisor) Run(ctx context.Context, services ...Service) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, len(services))
+	var wg sync.WaitGroup
+	for _, svc := range services {
+		wg.Add(1)
+		go func(svc Service) {
+			defer wg.Done()
+			if err := s.supervise(ctx, Name(svc), svc); err != nil {
+				errCh <- err
+			}
+		}(svc)
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errCh:
+		cancel()
+	}
+	wg.Wait()
+	return err
+}
+
+func (s *Supervisor) supervise(ctx context.Context, name string, svc Service) error {
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = 0
+	bo.Reset()
+
+	restarts := 0
+	for {
+		s.setStatus(name, StateRunning, restarts, nil)
+		started := time.Now()
+		err := svc.Start(ctx)
+		if ctx.Err() != nil {
+			s.setStatus(name, StateStopped, restarts, nil)
+			return nil
+		}
+
+		var permanent *permanentError
+		policy := Policy(s.c.SupervisorRestartPolicy(name))
+		switch {
+		case xerrors.As(err, &permanent):
+			s.setStatus(name, StateFailed, restarts, err)
+			return xerrors.Errorf("%s is failed: %w", name, err)
+		case err == nil && policy != PolicyAlways:
+			s.logger.Infof("%s is stopped", name)
+			s.setStatus(name, StateStopped, restarts, nil)
+			return nil
+		case err != nil && policy == PolicyNever:
+			s.setStatus(name, StateFailed, restarts, err)
+			return xerrors.Errorf("%s is failed: %w", name, err)
+		}
+
+		if time.Since(started) > s.stableDuration {
+			restarts = 0
+			bo.Reset()
+		}
+		if maxRestarts := s.c.SupervisorMaxRestarts(name); maxRestarts > 0 && restarts >= maxRestarts {
+			s.setStatus(name, StateFailed, restarts, err)
+			return xerrors.Errorf("%s is failed after %d restarts: %w", name, restarts, err)
+		}
+
+		bo.MaxInterval = time.Duration(s.c.SupervisorBackoffMaxSec()) * time.Second
+		wait := bo.NextBackOff()
+		// randomized interval may exceed max interval
+		if bo.MaxInterval > 0 && wait > bo.MaxInterval {
+			wait = bo.MaxInterval
+		}
+		restarts++
+		s.setStatus(name, StateBackoff, restarts, err)
+		if err != nil {
+			s.logger.Warnf("%s is stopped by error. restart in %s: %s", name, wait, err)
+		} else {
+			s.logger.Infof("%s is stopped. restart in %s", name, wait)
+		}
+
+		if !s.sleep(ctx, wait) {
+			s.setStatus(name, StateStopped, restarts, nil)
+			return nil
+		}
+	}
+}
+
+func (s *Supervisor) setStatus(name string, state State, restarts int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.statuses[name]; !ok {
+		s.names = append(s.names, name)
+	}
+	status := Status{
+		Name:     name,
+		State:    state,
+		Restarts: restarts,
+		Since:    time.Now(),
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	s.statuses[name] = status
+}
+
+// Statuses returns health of services in order of start
+func (s *Supervisor) Statuses() []Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses := make([]Status, 0, len(s.names))
+	for _, name := range s.names {
+		statuses = append(statuses, s.statuses[name])
+	}
+	return statuses
+}
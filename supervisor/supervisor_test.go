@@ -0,0 +1,190 @@
+package supervisor
+
+import (
+	"context"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+type registry struct{}
+
+func (registry) AppLogger(string) logrus.FieldLogger {
+	l := logrus.New()
+	l.SetOutput(ioutil.Discard)
+	return l
+}
+
+type configuration struct {
+	policy        Policy
+	maxRestarts   int
+	backoffMaxSec int
+}
+
+func (c configuration) SupervisorRestartPolicy(string) string { return string(c.policy) }
+func (c configuration) SupervisorMaxRestarts(string) int      { return c.maxRestarts }
+func (c configuration) SupervisorBackoffMaxSec() int          { return c.backoffMaxSec }
+
+// fakeService returns results in order on each start and blocks until ctx is done after them
+type fakeService struct {
+	results []func() error
+	starts  int
+}
+
+func (s *fakeService) Start(ctx context.Context) error {
+	s.starts++
+	if s.starts > len(s.results) {
+		<-ctx.Done()
+		return nil
+	}
+	return s.results[s.starts-1]()
+}
+
+func fail(err error) func() error {
+	return func() error { return err }
+}
+
+// newSupervisor returns supervisor which records backoff instead of sleeping
+func newSupervisor(c configuration) (*Supervisor, *[]time.Duration) {
+	s := New(registry{}, c)
+	var (
+		mu    sync.Mutex
+		waits []time.Duration
+	)
+	s.sleep = func(ctx context.Context, d time.Duration) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		waits = append(waits, d)
+		return ctx.Err() == nil
+	}
+	return s, &waits
+}
+
+// run runs svc until it gives up or settles, and returns the error
+func run(t *testing.T, s *Supervisor, svc Service) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.Run(ctx, svc)
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return <-errCh
+	}
+}
+
+var errBroken = xerrors.New("broken")
+
+func TestSupervisorPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		policy   Policy
+		result   func() error
+		starts   int
+		failed   bool
+		restarts int
+	}{
+		{policy: PolicyAlways, result: fail(nil), starts: 2, restarts: 1},
+		{policy: PolicyAlways, result: fail(errBroken), starts: 2, restarts: 1},
+		{policy: PolicyOnFailure, result: fail(nil), starts: 1},
+		{policy: PolicyOnFailure, result: fail(errBroken), starts: 2, restarts: 1},
+		{policy: PolicyNever, result: fail(nil), starts: 1},
+		{policy: PolicyNever, result: fail(errBroken), starts: 1, failed: true},
+	} {
+		s, _ := newSupervisor(configuration{policy: tc.policy, maxRestarts: 10})
+		svc := &fakeService{results: []func() error{tc.result}}
+		err := run(t, s, svc)
+
+		if failed := err != nil; failed != tc.failed {
+			t.Errorf("%s with %v: failed is %t, want %t", tc.policy, tc.result(), failed, tc.failed)
+		}
+		if svc.starts != tc.starts {
+			t.Errorf("%s with %v: started %d times, want %d", tc.policy, tc.result(), svc.starts, tc.starts)
+		}
+		if got := s.Statuses()[0].Restarts; got != tc.restarts {
+			t.Errorf("%s with %v: restarted %d times, want %d", tc.policy, tc.result(), got, tc.restarts)
+		}
+	}
+}
+
+func TestSupervisorMaxRestarts(t *testing.T) {
+	s, waits := newSupervisor(configuration{policy: PolicyAlways, maxRestarts: 3, backoffMaxSec: 60})
+	svc := &fakeService{results: []func() error{fail(errBroken), fail(errBroken), fail(errBroken), fail(errBroken), fail(errBroken)}}
+
+	err := run(t, s, svc)
+	if !xerrors.Is(err, errBroken) {
+		t.Fatalf("got %v, want error of service", err)
+	}
+	if svc.starts != 4 {
+		t.Errorf("started %d times, want 4", svc.starts)
+	}
+	if len(*waits) != 3 {
+		t.Errorf("waited %d times, want 3", len(*waits))
+	}
+	if st := s.Statuses()[0]; st.State != StateFailed || st.Restarts != 3 {
+		t.Errorf("status: got %s after %d restarts", st.State, st.Restarts)
+	}
+}
+
+func TestSupervisorResetAfterStable(t *testing.T) {
+	s, _ := newSupervisor(configuration{policy: PolicyAlways, maxRestarts: 2, backoffMaxSec: 60})
+	s.stableDuration = 20 * time.Millisecond
+
+	stable := func() error {
+		time.Sleep(30 * time.Millisecond)
+		return errBroken
+	}
+	svc := &fakeService{results: []func() error{fail(errBroken), stable, fail(errBroken), stable, fail(errBroken)}}
+
+	if err := run(t, s, svc); err != nil {
+		t.Fatalf("restart count is not reset by stable run: %s", err)
+	}
+	if svc.starts != 6 {
+		t.Errorf("started %d times, want 6", svc.starts)
+	}
+}
+
+func TestSupervisorBackoffMax(t *testing.T) {
+	s, waits := newSupervisor(configuration{policy: PolicyAlways, maxRestarts: 20, backoffMaxSec: 1})
+	var results []func() error
+	for i := 0; i < 15; i++ {
+		results = append(results, fail(errBroken))
+	}
+	svc := &fakeService{results: results}
+
+	if err := run(t, s, svc); err != nil {
+		t.Fatal(err)
+	}
+	for i, w := range *waits {
+		if w > time.Second {
+			t.Errorf("wait %d is %s over max", i, w)
+		}
+	}
+	if last := (*waits)[len(*waits)-1]; last < 500*time.Millisecond {
+		t.Errorf("backoff doesn't grow: last wait is %s", last)
+	}
+}
+
+func TestSupervisorPermanent(t *testing.T) {
+	s, waits := newSupervisor(configuration{policy: PolicyAlways, maxRestarts: 10})
+	svc := &fakeService{results: []func() error{fail(Permanent(errBroken))}}
+
+	err := run(t, s, svc)
+	if !xerrors.Is(err, errBroken) {
+		t.Fatalf("got %v, want error of service", err)
+	}
+	if svc.starts != 1 || len(*waits) != 0 {
+		t.Errorf("permanent error is retried: started %d times", svc.starts)
+	}
+	if st := s.Statuses()[0]; st.State != StateFailed {
+		t.Errorf("status: got %s, want %s", st.State, StateFailed)
+	}
+}
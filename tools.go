@@ -0,0 +1,9 @@
+//go:build tools
+// +build tools
+
+package main
+
+// tools used by go generate
+import (
+	_ "github.com/deepmap/oapi-codegen/cmd/oapi-codegen"
+)
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sawadashota/unifi-doorbell-chime/x/apiauth"
+)
+
+const sessionCookieName = "udc_session"
+
+// principal is who calls API
+type principal struct {
+	name   string
+	scopes apiauth.Scopes
+}
+
+var adminScopes = apiauth.Scopes{apiauth.ScopeAdmin}
+
+// authenticate identifies caller by bearer token, access_token query parameter or session cookie
+func (s *Server) authenticate(r *http.Request) (*principal, bool) {
+	if !s.c.APIAuthEnabled() {
+		return &principal{name: "anonymous", scopes: adminScopes}, true
+	}
+	if s.c.APIAuthTrustLoopback() && isLoopback(r, s.c.APIAuthTrustedProxies()) {
+		return &principal{name: "loopback", scopes: adminScopes}, true
+	}
+
+	if token := bearerToken(r); token != "" {
+		for _, t := range s.c.APITokens() {
+			if t.Verify(token) {
+				scopes, _ := apiauth.ParseScopes(t.Scopes)
+				return &principal{name: "token:" + t.Name, scopes: scopes}, true
+			}
+		}
+		return nil, false
+	}
+
+	if c, err := r.Cookie(sessionCookieName); err == nil {
+		if sess, ok := s.sessions.Get(c.Value); ok {
+			return &principal{name: "user:" + sess.Username, scopes: sess.Scopes}, true
+		}
+	}
+	return nil, false
+}
+
+// isLoopback reports whether r is sent from the host itself.
+// Request via trusted proxy is from the client in X-Forwarded-For, and proxy which doesn't tell it isn't trusted.
+// Forwarding headers from others are regarded as sent via unknown proxy.
+func isLoopback(r *http.Request, proxies []string) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	if !isTrustedProxy(ip, proxies) {
+		if r.Header.Get("X-Forwarded-For") != "" || r.Header.Get("Forwarded") != "" {
+			return false
+		}
+		return ip.IsLoopback()
+	}
+
+	// the last address is added by the trusted proxy and the others can be sent by client
+	forwarded := r.Header.Values("X-Forwarded-For")
+	if len(forwarded) == 0 {
+		return false
+	}
+	addrs := strings.Split(forwarded[len(forwarded)-1], ",")
+	client := net.ParseIP(strings.TrimSpace(addrs[len(addrs)-1]))
+	return client != nil && client.IsLoopback()
+}
+
+func isTrustedProxy(ip net.IP, proxies []string) bool {
+	for _, p := range proxies {
+		if _, n, err := net.ParseCIDR(p); err == nil {
+			if n.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if proxy := net.ParseIP(p); proxy != nil && proxy.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// bearerToken returns token of Authorization header.
+// access_token query parameter is also accepted for <img>, EventSource and WebSocket which can't set header.
+func bearerToken(r *http.Request) string {
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(h, "Bearer "))
+	}
+	return r.URL.Query().Get("access_token")
+}
+
+// require responds 401 to unauthenticated request and 403 to request without scope
+func (s *Server) require(scope apiauth.Scope, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p, ok := s.authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="unifi-doorbell-chime"`)
+			writeError(w, http.StatusUnauthorized, "authentication is required")
+			return
+		}
+		if !p.scopes.Allows(scope) {
+			s.logger.Warnf("%s is not allowed to %s %s", p.name, r.Method, r.URL.Path)
+			writeError(w, http.StatusForbidden, "%s scope is required", scope)
+			return
+		}
+		next(w, r)
+	}
+}
+
+var (
+	dummyPasswordHashOnce sync.Once
+	dummyPasswordHash     string
+)
+
+// dummyUser returns user compared when user is not found not to reveal existence of user by response time
+func dummyUser() apiauth.User {
+	dummyPasswordHashOnce.Do(func() {
+		dummyPasswordHash, _ = apiauth.HashPassword("dummy password")
+	})
+	return apiauth.User{PasswordHash: dummyPasswordHash}
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	param := struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}{}
+	defer func() {
+		if err := r.Body.Close(); err != nil {
+			s.logger.Error(err)
+		}
+	}()
+	if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+		return
+	}
+
+	user := dummyUser()
+	found := false
+	for _, u := range s.c.APIUsers() {
+		if u.Username == param.Username {
+			user = u
+			found = true
+			break
+		}
+	}
+	if !user.Verify(param.Password) || !found {
+		s.logger.Warnf("failed to log in as %q", param.Username)
+		writeError(w, http.StatusUnauthorized, "username or password is wrong")
+		return
+	}
+
+	scopes, _ := apiauth.ParseScopes(user.Scopes)
+	ttl := time.Duration(s.c.APISessionTTLSec()) * time.Second
+	sess, err := s.sessions.Create(user.Username, scopes, ttl)
+	if err != nil {
+		s.logger.Error(err)
+		writeError(w, http.StatusInternalServerError, "failed to create session")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    sess.ID,
+		Path:     "/",
+		Expires:  sess.ExpiresAt,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	s.logger.Infof("%s logged in", user.Username)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) logout(w http.ResponseWriter, r *http.Request) {
+	if c, err := r.Cookie(sessionCookieName); err == nil {
+		s.sessions.Delete(c.Value)
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// session tells frontend whether login is required
+func (s *Server) session(w http.ResponseWriter, r *http.Request) {
+	res := struct {
+		AuthEnabled   bool     `json:"auth_enabled"`
+		Authenticated bool     `json:"authenticated"`
+		Name          string   `json:"name,omitempty"`
+		Scopes        []string `json:"scopes"`
+	}{
+		AuthEnabled: s.c.APIAuthEnabled(),
+		Scopes:      []string{},
+	}
+	if p, ok := s.authenticate(r); ok {
+		res.Authenticated = true
+		res.Name = p.name
+		for _, scope := range p.scopes {
+			res.Scopes = append(res.Scopes, string(scope))
+		}
+	}
+
+	s.writeJSON(w, http.StatusOK, &res)
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsLoopback(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		remote    string
+		forwarded []string
+		proxies   []string
+		want      bool
+	}{
+		{name: "loopback", remote: "127.0.0.1:50000", want: true},
+		{name: "ipv6 loopback", remote: "[::1]:50000", want: true},
+		{name: "lan", remote: "192.168.1.10:50000"},
+		{name: "unknown proxy with header", remote: "127.0.0.1:50000", forwarded: []string{"192.168.1.10"}},
+		{name: "unknown proxy spoofing loopback", remote: "127.0.0.1:50000", forwarded: []string{"127.0.0.1"}},
+		{name: "trusted proxy without header", remote: "127.0.0.1:50000", proxies: []string{"127.0.0.1"}},
+		{name: "trusted proxy for lan", remote: "127.0.0.1:50000", forwarded: []string{"192.168.1.10"}, proxies: []string{"127.0.0.1"}},
+		{name: "trusted proxy for loopback", remote: "127.0.0.1:50000", forwarded: []string{"127.0.0.1"}, proxies: []string{"127.0.0.1"}, want: true},
+		{name: "spoofed header before trusted proxy", remote: "127.0.0.1:50000", forwarded: []string{"127.0.0.1, 192.168.1.10"}, proxies: []string{"127.0.0.0/8"}},
+		{name: "spoofed header line before trusted proxy", remote: "127.0.0.1:50000", forwarded: []string{"127.0.0.1", "192.168.1.10"}, proxies: []string{"127.0.0.1"}},
+		{name: "trusted proxy in cidr", remote: "10.0.0.2:50000", forwarded: []string{"::1"}, proxies: []string{"10.0.0.0/24"}, want: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/doorbells", nil)
+			r.RemoteAddr = tc.remote
+			for _, f := range tc.forwarded {
+				r.Header.Add("X-Forwarded-For", f)
+			}
+			if got := isLoopback(r, tc.proxies); got != tc.want {
+				t.Errorf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sawadashota/unifi-doorbell-chime/chime"
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"golang.org/x/xerrors"
+)
+
+// writeChimeError responds error of chime controller
+func (s *Server) writeChimeError(w http.ResponseWriter, err error) {
+	switch {
+	case xerrors.Is(err, chime.ErrNoChime), xerrors.Is(err, chime.ErrInvalidSettings):
+		writeError(w, http.StatusBadRequest, "%s", err)
+	case xerrors.Is(err, unifi.ErrChimeTestUnsupported):
+		writeError(w, http.StatusConflict, "%s", unifi.ErrChimeTestUnsupported)
+	default:
+		s.writeUpstreamError(w, err)
+	}
+}
+
+func (s *Server) getChime(w http.ResponseWriter, r *http.Request) {
+	doorbellID := mux.Vars(r)["doorbellID"]
+
+	status, err := s.r.Chime().Status(r.Context(), doorbellID)
+	if err != nil {
+		s.writeChimeError(w, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) updateChime(w http.ResponseWriter, r *http.Request) {
+	doorbellID := mux.Vars(r)["doorbellID"]
+
+	var settings chime.Settings
+	defer func() {
+		if err := r.Body.Close(); err != nil {
+			s.logger.Error(err)
+		}
+	}()
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+		return
+	}
+
+	status, err := s.r.Chime().Set(r.Context(), doorbellID, settings)
+	if err != nil {
+		s.writeChimeError(w, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) testChime(w http.ResponseWriter, r *http.Request) {
+	doorbellID := mux.Vars(r)["doorbellID"]
+
+	if err := s.r.Chime().Test(r.Context(), doorbellID); err != nil {
+		s.writeChimeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,3107 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v1.8.2 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+)
+
+const (
+	AccessTokenScopes   = "accessToken.Scopes"
+	BearerAuthScopes    = "bearerAuth.Scopes"
+	SessionCookieScopes = "sessionCookie.Scopes"
+)
+
+// Defines values for ChimeType.
+const (
+	ChimeTypeDigital ChimeType = "digital"
+
+	ChimeTypeMechanical ChimeType = "mechanical"
+)
+
+// Defines values for ChimeSettingsType.
+const (
+	ChimeSettingsTypeDigital ChimeSettingsType = "digital"
+
+	ChimeSettingsTypeMechanical ChimeSettingsType = "mechanical"
+)
+
+// Defines values for DoorbellSettingsIrMode.
+const (
+	DoorbellSettingsIrModeAuto DoorbellSettingsIrMode = "auto"
+
+	DoorbellSettingsIrModeAutoFilterOnly DoorbellSettingsIrMode = "autoFilterOnly"
+
+	DoorbellSettingsIrModeOff DoorbellSettingsIrMode = "off"
+
+	DoorbellSettingsIrModeOn DoorbellSettingsIrMode = "on"
+)
+
+// Defines values for EventPackage.
+const (
+	EventPackageDelivered EventPackage = "delivered"
+
+	EventPackagePickedUp EventPackage = "picked_up"
+)
+
+// Defines values for EventStatus.
+const (
+	EventStatusDown EventStatus = "down"
+
+	EventStatusUp EventStatus = "up"
+)
+
+// Defines values for EventType.
+const (
+	EventTypeHealth EventType = "health"
+
+	EventTypeMessage EventType = "message"
+
+	EventTypeMotion EventType = "motion"
+
+	EventTypePackage EventType = "package"
+
+	EventTypeRing EventType = "ring"
+)
+
+// Defines values for SessionScopes.
+const (
+	SessionScopesAdmin SessionScopes = "admin"
+
+	SessionScopesMessage SessionScopes = "message"
+
+	SessionScopesRead SessionScopes = "read"
+
+	SessionScopesSettings SessionScopes = "settings"
+)
+
+// Chime defines model for Chime.
+type Chime struct {
+	// Do not disturb hours when chime is disabled automatically
+	Dnd          DND    `json:"dnd"`
+	DoorbellId   string `json:"doorbell_id"`
+	DoorbellName string `json:"doorbell_name"`
+
+	// 300 for mechanical chime or 1000 to 10000 for digital chime
+	DurationMs *int `json:"duration_ms,omitempty"`
+	Enabled    bool `json:"enabled"`
+
+	// Restored when enabled again if chime is disabled. Absent if it has never been enabled
+	Type *ChimeType `json:"type,omitempty"`
+}
+
+// Restored when enabled again if chime is disabled. Absent if it has never been enabled
+type ChimeType string
+
+// ChimeSettings defines model for ChimeSettings.
+type ChimeSettings struct {
+	// How long digital chime rings
+	DurationMs *int               `json:"duration_ms,omitempty"`
+	Enabled    *bool              `json:"enabled,omitempty"`
+	Type       *ChimeSettingsType `json:"type,omitempty"`
+}
+
+// ChimeSettingsType defines model for ChimeSettings.Type.
+type ChimeSettingsType string
+
+// Do not disturb hours when chime is disabled automatically
+type DND struct {
+	// Whether it is DND hours now
+	Active  bool    `json:"active"`
+	Enabled bool    `json:"enabled"`
+	End     *string `json:"end,omitempty"`
+	Start   *string `json:"start,omitempty"`
+}
+
+// Doorbell defines model for Doorbell.
+type Doorbell struct {
+	// Unix time in milliseconds
+	ConnectedSince *int64 `json:"connected_since,omitempty"`
+	Firmware       string `json:"firmware"`
+
+	// True if package snapshot is available like G4 Doorbell Pro
+	HasPackageCamera bool    `json:"has_package_camera"`
+	Host             *string `json:"host,omitempty"`
+	Id               string  `json:"id"`
+	IsConnected      bool    `json:"is_connected"`
+
+	// Unix time in milliseconds. 0 if no motion is detected
+	LastMotion int64 `json:"last_motion"`
+
+	// Unix time in milliseconds. 0 if never rung
+	LastRing int64 `json:"last_ring"`
+
+	// Message shown on doorbell screen. Absent if default message is shown
+	LcdMessage    *LcdMessage      `json:"lcd_message,omitempty"`
+	Mac           string           `json:"mac"`
+	Model         string           `json:"model"`
+	Name          string           `json:"name"`
+	Settings      DoorbellSettings `json:"settings"`
+	SpeakerVolume int              `json:"speaker_volume"`
+	State         string           `json:"state"`
+
+	// Wi-Fi connection. Absent if doorbell is wired
+	Wifi *Wifi `json:"wifi,omitempty"`
+}
+
+// DoorbellList defines model for DoorbellList.
+type DoorbellList struct {
+	Doorbells []Doorbell `json:"doorbells"`
+}
+
+// DoorbellSettings defines model for DoorbellSettings.
+type DoorbellSettings struct {
+	// 0 for no chime, 300 for mechanical chime or 1000 to 10000 for digital chime
+	ChimeDurationMs *int                    `json:"chime_duration_ms,omitempty"`
+	IrMode          *DoorbellSettingsIrMode `json:"ir_mode,omitempty"`
+
+	// Status LED
+	LedEnabled *bool `json:"led_enabled,omitempty"`
+
+	// Show date on video
+	OsdDateEnabled *bool `json:"osd_date_enabled,omitempty"`
+
+	// Show name on video
+	OsdNameEnabled *bool `json:"osd_name_enabled,omitempty"`
+	SpeakerVolume  *int  `json:"speaker_volume,omitempty"`
+}
+
+// DoorbellSettingsIrMode defines model for DoorbellSettings.IrMode.
+type DoorbellSettingsIrMode string
+
+// Error defines model for Error.
+type Error struct {
+	// Status text in snake case such as not_found
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Event defines model for Event.
+type Event struct {
+	DoorbellId   *string `json:"doorbell_id,omitempty"`
+	DoorbellName *string `json:"doorbell_name,omitempty"`
+	Error        *string `json:"error,omitempty"`
+	Id           string  `json:"id"`
+	Message      *string `json:"message,omitempty"`
+
+	// What happened to package of package event
+	Package *EventPackage `json:"package,omitempty"`
+	Status  *EventStatus  `json:"status,omitempty"`
+
+	// True if the event is injected for testing
+	Synthetic *bool     `json:"synthetic,omitempty"`
+	Time      time.Time `json:"time"`
+	Type      EventType `json:"type"`
+}
+
+// What happened to package of package event
+type EventPackage string
+
+// EventStatus defines model for Event.Status.
+type EventStatus string
+
+// EventType defines model for Event.Type.
+type EventType string
+
+// Message shown on doorbell screen. Absent if default message is shown
+type LcdMessage struct {
+	// Unix time in milliseconds when message is reset. Absent if it is shown forever
+	ResetAt *int64 `json:"reset_at,omitempty"`
+	Text    string `json:"text"`
+	Type    string `json:"type"`
+}
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	Password string `json:"password"`
+	Username string `json:"username"`
+}
+
+// MessageTemplates defines model for MessageTemplates.
+type MessageTemplates struct {
+	Templates []string `json:"templates"`
+}
+
+// RingReport defines model for RingReport.
+type RingReport struct {
+	Event Event        `json:"event"`
+	Steps []StepResult `json:"steps"`
+}
+
+// Session defines model for Session.
+type Session struct {
+	AuthEnabled   bool            `json:"auth_enabled"`
+	Authenticated bool            `json:"authenticated"`
+	Name          *string         `json:"name,omitempty"`
+	Scopes        []SessionScopes `json:"scopes"`
+}
+
+// SessionScopes defines model for Session.Scopes.
+type SessionScopes string
+
+// SetMessageRequest defines model for SetMessageRequest.
+type SetMessageRequest struct {
+	DoorbellId  string `json:"doorbell_id"`
+	DurationSec *int   `json:"duration_sec,omitempty"`
+	Message     string `json:"message"`
+}
+
+// StepResult defines model for StepResult.
+type StepResult struct {
+	Error   *string `json:"error,omitempty"`
+	Name    string  `json:"name"`
+	Ok      bool    `json:"ok"`
+	Skipped *bool   `json:"skipped,omitempty"`
+}
+
+// Wi-Fi connection. Absent if doorbell is wired
+type Wifi struct {
+	// Percentage
+	SignalQuality int `json:"signal_quality"`
+
+	// dBm
+	SignalStrength int `json:"signal_strength"`
+}
+
+// DoorbellID defines model for DoorbellID.
+type DoorbellID string
+
+// BadGateway defines model for BadGateway.
+type BadGateway Error
+
+// BadRequest defines model for BadRequest.
+type BadRequest Error
+
+// Forbidden defines model for Forbidden.
+type Forbidden Error
+
+// NotFound defines model for NotFound.
+type NotFound Error
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized Error
+
+// LoginJSONBody defines parameters for Login.
+type LoginJSONBody LoginRequest
+
+// UpdateChimeJSONBody defines parameters for UpdateChime.
+type UpdateChimeJSONBody ChimeSettings
+
+// UpdateDoorbellSettingsJSONBody defines parameters for UpdateDoorbellSettings.
+type UpdateDoorbellSettingsJSONBody DoorbellSettings
+
+// SetMessageJSONBody defines parameters for SetMessage.
+type SetMessageJSONBody SetMessageRequest
+
+// TalkbackParams defines parameters for Talkback.
+type TalkbackParams struct {
+	// Sample rate of PCM
+	Rate *int `json:"rate,omitempty"`
+}
+
+// LoginJSONRequestBody defines body for Login for application/json ContentType.
+type LoginJSONRequestBody LoginJSONBody
+
+// UpdateChimeJSONRequestBody defines body for UpdateChime for application/json ContentType.
+type UpdateChimeJSONRequestBody UpdateChimeJSONBody
+
+// UpdateDoorbellSettingsJSONRequestBody defines body for UpdateDoorbellSettings for application/json ContentType.
+type UpdateDoorbellSettingsJSONRequestBody UpdateDoorbellSettingsJSONBody
+
+// SetMessageJSONRequestBody defines body for SetMessage for application/json ContentType.
+type SetMessageJSONRequestBody SetMessageJSONBody
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// Login request with any body
+	LoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Login(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Logout request
+	Logout(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSession request
+	GetSession(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SimulateRing request
+	SimulateRing(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListDoorbells request
+	ListDoorbells(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDoorbell request
+	GetDoorbell(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChime request
+	GetChime(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateChime request with any body
+	UpdateChimeWithBody(ctx context.Context, doorbellID DoorbellID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateChime(ctx context.Context, doorbellID DoorbellID, body UpdateChimeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TestChime request
+	TestChime(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateDoorbellSettings request with any body
+	UpdateDoorbellSettingsWithBody(ctx context.Context, doorbellID DoorbellID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateDoorbellSettings(ctx context.Context, doorbellID DoorbellID, body UpdateDoorbellSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StreamEvents request
+	StreamEvents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StreamEventsWebSocket request
+	StreamEventsWebSocket(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetClip request
+	GetClip(ctx context.Context, eventID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEventSnapshot request
+	GetEventSnapshot(ctx context.Context, eventID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetMessage request with any body
+	SetMessageWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetMessage(ctx context.Context, body SetMessageJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListMessageTemplates request
+	ListMessageTemplates(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSnapshot request
+	GetSnapshot(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPackageSnapshot request
+	GetPackageSnapshot(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLiveStream request
+	GetLiveStream(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Talkback request
+	Talkback(ctx context.Context, doorbellID DoorbellID, params *TalkbackParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) LoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Login(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Logout(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLogoutRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSession(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSessionRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SimulateRing(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSimulateRingRequest(c.Server, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListDoorbells(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListDoorbellsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetDoorbell(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDoorbellRequest(c.Server, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetChime(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChimeRequest(c.Server, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateChimeWithBody(ctx context.Context, doorbellID DoorbellID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateChimeRequestWithBody(c.Server, doorbellID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateChime(ctx context.Context, doorbellID DoorbellID, body UpdateChimeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateChimeRequest(c.Server, doorbellID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TestChime(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTestChimeRequest(c.Server, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateDoorbellSettingsWithBody(ctx context.Context, doorbellID DoorbellID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateDoorbellSettingsRequestWithBody(c.Server, doorbellID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateDoorbellSettings(ctx context.Context, doorbellID DoorbellID, body UpdateDoorbellSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateDoorbellSettingsRequest(c.Server, doorbellID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StreamEvents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamEventsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StreamEventsWebSocket(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamEventsWebSocketRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetClip(ctx context.Context, eventID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetClipRequest(c.Server, eventID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEventSnapshot(ctx context.Context, eventID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEventSnapshotRequest(c.Server, eventID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetMessageWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetMessageRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetMessage(ctx context.Context, body SetMessageJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetMessageRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListMessageTemplates(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListMessageTemplatesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSnapshot(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSnapshotRequest(c.Server, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPackageSnapshot(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPackageSnapshotRequest(c.Server, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLiveStream(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLiveStreamRequest(c.Server, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Talkback(ctx context.Context, doorbellID DoorbellID, params *TalkbackParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTalkbackRequest(c.Server, doorbellID, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewLoginRequest calls the generic Login builder with application/json body
+func NewLoginRequest(server string, body LoginJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewLoginRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewLoginRequestWithBody generates requests for Login with any type of body
+func NewLoginRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/login")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewLogoutRequest generates requests for Logout
+func NewLogoutRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/logout")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSessionRequest generates requests for GetSession
+func NewGetSessionRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/session")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSimulateRingRequest generates requests for SimulateRing
+func NewSimulateRingRequest(server string, doorbellID DoorbellID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "doorbellID", runtime.ParamLocationPath, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/debug/ring/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListDoorbellsRequest generates requests for ListDoorbells
+func NewListDoorbellsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/doorbells")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetDoorbellRequest generates requests for GetDoorbell
+func NewGetDoorbellRequest(server string, doorbellID DoorbellID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "doorbellID", runtime.ParamLocationPath, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/doorbells/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetChimeRequest generates requests for GetChime
+func NewGetChimeRequest(server string, doorbellID DoorbellID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "doorbellID", runtime.ParamLocationPath, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/doorbells/%s/chime", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateChimeRequest calls the generic UpdateChime builder with application/json body
+func NewUpdateChimeRequest(server string, doorbellID DoorbellID, body UpdateChimeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateChimeRequestWithBody(server, doorbellID, "application/json", bodyReader)
+}
+
+// NewUpdateChimeRequestWithBody generates requests for UpdateChime with any type of body
+func NewUpdateChimeRequestWithBody(server string, doorbellID DoorbellID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "doorbellID", runtime.ParamLocationPath, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/doorbells/%s/chime", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTestChimeRequest generates requests for TestChime
+func NewTestChimeRequest(server string, doorbellID DoorbellID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "doorbellID", runtime.ParamLocationPath, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/doorbells/%s/chime/test", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateDoorbellSettingsRequest calls the generic UpdateDoorbellSettings builder with application/json body
+func NewUpdateDoorbellSettingsRequest(server string, doorbellID DoorbellID, body UpdateDoorbellSettingsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateDoorbellSettingsRequestWithBody(server, doorbellID, "application/json", bodyReader)
+}
+
+// NewUpdateDoorbellSettingsRequestWithBody generates requests for UpdateDoorbellSettings with any type of body
+func NewUpdateDoorbellSettingsRequestWithBody(server string, doorbellID DoorbellID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "doorbellID", runtime.ParamLocationPath, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/doorbells/%s/settings", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewStreamEventsRequest generates requests for StreamEvents
+func NewStreamEventsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/events/stream")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStreamEventsWebSocketRequest generates requests for StreamEventsWebSocket
+func NewStreamEventsWebSocketRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/events/ws")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetClipRequest generates requests for GetClip
+func NewGetClipRequest(server string, eventID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "eventID", runtime.ParamLocationPath, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/events/%s/clip", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEventSnapshotRequest generates requests for GetEventSnapshot
+func NewGetEventSnapshotRequest(server string, eventID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "eventID", runtime.ParamLocationPath, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/events/%s/snapshot", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetMessageRequest calls the generic SetMessage builder with application/json body
+func NewSetMessageRequest(server string, body SetMessageJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetMessageRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSetMessageRequestWithBody generates requests for SetMessage with any type of body
+func NewSetMessageRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/message/set")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListMessageTemplatesRequest generates requests for ListMessageTemplates
+func NewListMessageTemplatesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/message/templates")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSnapshotRequest generates requests for GetSnapshot
+func NewGetSnapshotRequest(server string, doorbellID DoorbellID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "doorbellID", runtime.ParamLocationPath, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/snapshot/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPackageSnapshotRequest generates requests for GetPackageSnapshot
+func NewGetPackageSnapshotRequest(server string, doorbellID DoorbellID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "doorbellID", runtime.ParamLocationPath, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/snapshot/%s/package", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetLiveStreamRequest generates requests for GetLiveStream
+func NewGetLiveStreamRequest(server string, doorbellID DoorbellID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "doorbellID", runtime.ParamLocationPath, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stream/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTalkbackRequest generates requests for Talkback
+func NewTalkbackRequest(server string, doorbellID DoorbellID, params *TalkbackParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "doorbellID", runtime.ParamLocationPath, doorbellID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/talkback/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Rate != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "rate", runtime.ParamLocationQuery, *params.Rate); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// Login request with any body
+	LoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginResponse, error)
+
+	LoginWithResponse(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginResponse, error)
+
+	// Logout request
+	LogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LogoutResponse, error)
+
+	// GetSession request
+	GetSessionWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSessionResponse, error)
+
+	// SimulateRing request
+	SimulateRingWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*SimulateRingResponse, error)
+
+	// ListDoorbells request
+	ListDoorbellsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListDoorbellsResponse, error)
+
+	// GetDoorbell request
+	GetDoorbellWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*GetDoorbellResponse, error)
+
+	// GetChime request
+	GetChimeWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*GetChimeResponse, error)
+
+	// UpdateChime request with any body
+	UpdateChimeWithBodyWithResponse(ctx context.Context, doorbellID DoorbellID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateChimeResponse, error)
+
+	UpdateChimeWithResponse(ctx context.Context, doorbellID DoorbellID, body UpdateChimeJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateChimeResponse, error)
+
+	// TestChime request
+	TestChimeWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*TestChimeResponse, error)
+
+	// UpdateDoorbellSettings request with any body
+	UpdateDoorbellSettingsWithBodyWithResponse(ctx context.Context, doorbellID DoorbellID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateDoorbellSettingsResponse, error)
+
+	UpdateDoorbellSettingsWithResponse(ctx context.Context, doorbellID DoorbellID, body UpdateDoorbellSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateDoorbellSettingsResponse, error)
+
+	// StreamEvents request
+	StreamEventsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*StreamEventsResponse, error)
+
+	// StreamEventsWebSocket request
+	StreamEventsWebSocketWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*StreamEventsWebSocketResponse, error)
+
+	// GetClip request
+	GetClipWithResponse(ctx context.Context, eventID string, reqEditors ...RequestEditorFn) (*GetClipResponse, error)
+
+	// GetEventSnapshot request
+	GetEventSnapshotWithResponse(ctx context.Context, eventID string, reqEditors ...RequestEditorFn) (*GetEventSnapshotResponse, error)
+
+	// SetMessage request with any body
+	SetMessageWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetMessageResponse, error)
+
+	SetMessageWithResponse(ctx context.Context, body SetMessageJSONRequestBody, reqEditors ...RequestEditorFn) (*SetMessageResponse, error)
+
+	// ListMessageTemplates request
+	ListMessageTemplatesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListMessageTemplatesResponse, error)
+
+	// GetSnapshot request
+	GetSnapshotWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*GetSnapshotResponse, error)
+
+	// GetPackageSnapshot request
+	GetPackageSnapshotWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*GetPackageSnapshotResponse, error)
+
+	// GetLiveStream request
+	GetLiveStreamWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*GetLiveStreamResponse, error)
+
+	// Talkback request
+	TalkbackWithResponse(ctx context.Context, doorbellID DoorbellID, params *TalkbackParams, reqEditors ...RequestEditorFn) (*TalkbackResponse, error)
+}
+
+type LoginResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r LoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LogoutResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r LogoutResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LogoutResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Session
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SimulateRingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RingReport
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SimulateRingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SimulateRingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListDoorbellsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DoorbellList
+	JSON401      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListDoorbellsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListDoorbellsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDoorbellResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Doorbell
+	JSON401      *Error
+	JSON404      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDoorbellResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDoorbellResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChimeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Chime
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChimeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChimeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateChimeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Chime
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateChimeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateChimeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TestChimeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON409      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r TestChimeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TestChimeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateDoorbellSettingsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Doorbell
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateDoorbellSettingsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateDoorbellSettingsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamEventsWebSocketResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamEventsWebSocketResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamEventsWebSocketResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetClipResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetClipResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetClipResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEventSnapshotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEventSnapshotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEventSnapshotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetMessageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SetMessageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetMessageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListMessageTemplatesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageTemplates
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListMessageTemplatesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListMessageTemplatesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSnapshotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON404      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSnapshotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSnapshotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPackageSnapshotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON404      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPackageSnapshotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPackageSnapshotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLiveStreamResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON404      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLiveStreamResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLiveStreamResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TalkbackResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON502      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r TalkbackResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TalkbackResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// LoginWithBodyWithResponse request with arbitrary body returning *LoginResponse
+func (c *ClientWithResponses) LoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginResponse, error) {
+	rsp, err := c.LoginWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginResponse(rsp)
+}
+
+func (c *ClientWithResponses) LoginWithResponse(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginResponse, error) {
+	rsp, err := c.Login(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginResponse(rsp)
+}
+
+// LogoutWithResponse request returning *LogoutResponse
+func (c *ClientWithResponses) LogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LogoutResponse, error) {
+	rsp, err := c.Logout(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLogoutResponse(rsp)
+}
+
+// GetSessionWithResponse request returning *GetSessionResponse
+func (c *ClientWithResponses) GetSessionWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSessionResponse, error) {
+	rsp, err := c.GetSession(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSessionResponse(rsp)
+}
+
+// SimulateRingWithResponse request returning *SimulateRingResponse
+func (c *ClientWithResponses) SimulateRingWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*SimulateRingResponse, error) {
+	rsp, err := c.SimulateRing(ctx, doorbellID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSimulateRingResponse(rsp)
+}
+
+// ListDoorbellsWithResponse request returning *ListDoorbellsResponse
+func (c *ClientWithResponses) ListDoorbellsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListDoorbellsResponse, error) {
+	rsp, err := c.ListDoorbells(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListDoorbellsResponse(rsp)
+}
+
+// GetDoorbellWithResponse request returning *GetDoorbellResponse
+func (c *ClientWithResponses) GetDoorbellWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*GetDoorbellResponse, error) {
+	rsp, err := c.GetDoorbell(ctx, doorbellID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDoorbellResponse(rsp)
+}
+
+// GetChimeWithResponse request returning *GetChimeResponse
+func (c *ClientWithResponses) GetChimeWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*GetChimeResponse, error) {
+	rsp, err := c.GetChime(ctx, doorbellID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChimeResponse(rsp)
+}
+
+// UpdateChimeWithBodyWithResponse request with arbitrary body returning *UpdateChimeResponse
+func (c *ClientWithResponses) UpdateChimeWithBodyWithResponse(ctx context.Context, doorbellID DoorbellID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateChimeResponse, error) {
+	rsp, err := c.UpdateChimeWithBody(ctx, doorbellID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateChimeResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateChimeWithResponse(ctx context.Context, doorbellID DoorbellID, body UpdateChimeJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateChimeResponse, error) {
+	rsp, err := c.UpdateChime(ctx, doorbellID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateChimeResponse(rsp)
+}
+
+// TestChimeWithResponse request returning *TestChimeResponse
+func (c *ClientWithResponses) TestChimeWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*TestChimeResponse, error) {
+	rsp, err := c.TestChime(ctx, doorbellID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTestChimeResponse(rsp)
+}
+
+// UpdateDoorbellSettingsWithBodyWithResponse request with arbitrary body returning *UpdateDoorbellSettingsResponse
+func (c *ClientWithResponses) UpdateDoorbellSettingsWithBodyWithResponse(ctx context.Context, doorbellID DoorbellID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateDoorbellSettingsResponse, error) {
+	rsp, err := c.UpdateDoorbellSettingsWithBody(ctx, doorbellID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateDoorbellSettingsResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateDoorbellSettingsWithResponse(ctx context.Context, doorbellID DoorbellID, body UpdateDoorbellSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateDoorbellSettingsResponse, error) {
+	rsp, err := c.UpdateDoorbellSettings(ctx, doorbellID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateDoorbellSettingsResponse(rsp)
+}
+
+// StreamEventsWithResponse request returning *StreamEventsResponse
+func (c *ClientWithResponses) StreamEventsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*StreamEventsResponse, error) {
+	rsp, err := c.StreamEvents(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamEventsResponse(rsp)
+}
+
+// StreamEventsWebSocketWithResponse request returning *StreamEventsWebSocketResponse
+func (c *ClientWithResponses) StreamEventsWebSocketWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*StreamEventsWebSocketResponse, error) {
+	rsp, err := c.StreamEventsWebSocket(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamEventsWebSocketResponse(rsp)
+}
+
+// GetClipWithResponse request returning *GetClipResponse
+func (c *ClientWithResponses) GetClipWithResponse(ctx context.Context, eventID string, reqEditors ...RequestEditorFn) (*GetClipResponse, error) {
+	rsp, err := c.GetClip(ctx, eventID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetClipResponse(rsp)
+}
+
+// GetEventSnapshotWithResponse request returning *GetEventSnapshotResponse
+func (c *ClientWithResponses) GetEventSnapshotWithResponse(ctx context.Context, eventID string, reqEditors ...RequestEditorFn) (*GetEventSnapshotResponse, error) {
+	rsp, err := c.GetEventSnapshot(ctx, eventID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEventSnapshotResponse(rsp)
+}
+
+// SetMessageWithBodyWithResponse request with arbitrary body returning *SetMessageResponse
+func (c *ClientWithResponses) SetMessageWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetMessageResponse, error) {
+	rsp, err := c.SetMessageWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetMessageResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetMessageWithResponse(ctx context.Context, body SetMessageJSONRequestBody, reqEditors ...RequestEditorFn) (*SetMessageResponse, error) {
+	rsp, err := c.SetMessage(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetMessageResponse(rsp)
+}
+
+// ListMessageTemplatesWithResponse request returning *ListMessageTemplatesResponse
+func (c *ClientWithResponses) ListMessageTemplatesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListMessageTemplatesResponse, error) {
+	rsp, err := c.ListMessageTemplates(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListMessageTemplatesResponse(rsp)
+}
+
+// GetSnapshotWithResponse request returning *GetSnapshotResponse
+func (c *ClientWithResponses) GetSnapshotWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*GetSnapshotResponse, error) {
+	rsp, err := c.GetSnapshot(ctx, doorbellID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSnapshotResponse(rsp)
+}
+
+// GetPackageSnapshotWithResponse request returning *GetPackageSnapshotResponse
+func (c *ClientWithResponses) GetPackageSnapshotWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*GetPackageSnapshotResponse, error) {
+	rsp, err := c.GetPackageSnapshot(ctx, doorbellID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPackageSnapshotResponse(rsp)
+}
+
+// GetLiveStreamWithResponse request returning *GetLiveStreamResponse
+func (c *ClientWithResponses) GetLiveStreamWithResponse(ctx context.Context, doorbellID DoorbellID, reqEditors ...RequestEditorFn) (*GetLiveStreamResponse, error) {
+	rsp, err := c.GetLiveStream(ctx, doorbellID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLiveStreamResponse(rsp)
+}
+
+// TalkbackWithResponse request returning *TalkbackResponse
+func (c *ClientWithResponses) TalkbackWithResponse(ctx context.Context, doorbellID DoorbellID, params *TalkbackParams, reqEditors ...RequestEditorFn) (*TalkbackResponse, error) {
+	rsp, err := c.Talkback(ctx, doorbellID, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTalkbackResponse(rsp)
+}
+
+// ParseLoginResponse parses an HTTP response from a LoginWithResponse call
+func ParseLoginResponse(rsp *http.Response) (*LoginResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LoginResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLogoutResponse parses an HTTP response from a LogoutWithResponse call
+func ParseLogoutResponse(rsp *http.Response) (*LogoutResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LogoutResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetSessionResponse parses an HTTP response from a GetSessionWithResponse call
+func ParseGetSessionResponse(rsp *http.Response) (*GetSessionResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Session
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSimulateRingResponse parses an HTTP response from a SimulateRingWithResponse call
+func ParseSimulateRingResponse(rsp *http.Response) (*SimulateRingResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SimulateRingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RingReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListDoorbellsResponse parses an HTTP response from a ListDoorbellsWithResponse call
+func ParseListDoorbellsResponse(rsp *http.Response) (*ListDoorbellsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListDoorbellsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DoorbellList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetDoorbellResponse parses an HTTP response from a GetDoorbellWithResponse call
+func ParseGetDoorbellResponse(rsp *http.Response) (*GetDoorbellResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDoorbellResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Doorbell
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChimeResponse parses an HTTP response from a GetChimeWithResponse call
+func ParseGetChimeResponse(rsp *http.Response) (*GetChimeResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChimeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Chime
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateChimeResponse parses an HTTP response from a UpdateChimeWithResponse call
+func ParseUpdateChimeResponse(rsp *http.Response) (*UpdateChimeResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateChimeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Chime
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTestChimeResponse parses an HTTP response from a TestChimeWithResponse call
+func ParseTestChimeResponse(rsp *http.Response) (*TestChimeResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TestChimeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateDoorbellSettingsResponse parses an HTTP response from a UpdateDoorbellSettingsWithResponse call
+func ParseUpdateDoorbellSettingsResponse(rsp *http.Response) (*UpdateDoorbellSettingsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateDoorbellSettingsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Doorbell
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStreamEventsResponse parses an HTTP response from a StreamEventsWithResponse call
+func ParseStreamEventsResponse(rsp *http.Response) (*StreamEventsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStreamEventsWebSocketResponse parses an HTTP response from a StreamEventsWebSocketWithResponse call
+func ParseStreamEventsWebSocketResponse(rsp *http.Response) (*StreamEventsWebSocketResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamEventsWebSocketResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetClipResponse parses an HTTP response from a GetClipWithResponse call
+func ParseGetClipResponse(rsp *http.Response) (*GetClipResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetClipResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEventSnapshotResponse parses an HTTP response from a GetEventSnapshotWithResponse call
+func ParseGetEventSnapshotResponse(rsp *http.Response) (*GetEventSnapshotResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEventSnapshotResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetMessageResponse parses an HTTP response from a SetMessageWithResponse call
+func ParseSetMessageResponse(rsp *http.Response) (*SetMessageResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetMessageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListMessageTemplatesResponse parses an HTTP response from a ListMessageTemplatesWithResponse call
+func ParseListMessageTemplatesResponse(rsp *http.Response) (*ListMessageTemplatesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListMessageTemplatesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageTemplates
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSnapshotResponse parses an HTTP response from a GetSnapshotWithResponse call
+func ParseGetSnapshotResponse(rsp *http.Response) (*GetSnapshotResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSnapshotResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPackageSnapshotResponse parses an HTTP response from a GetPackageSnapshotWithResponse call
+func ParseGetPackageSnapshotResponse(rsp *http.Response) (*GetPackageSnapshotResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPackageSnapshotResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLiveStreamResponse parses an HTTP response from a GetLiveStreamWithResponse call
+func ParseGetLiveStreamResponse(rsp *http.Response) (*GetLiveStreamResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLiveStreamResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTalkbackResponse parses an HTTP response from a TalkbackWithResponse call
+func ParseTalkbackResponse(rsp *http.Response) (*TalkbackResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TalkbackResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
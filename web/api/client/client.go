@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// New returns client of API, e.g. http://127.0.0.1:8080/api/v1.
+// Requests are authenticated by token unless it is empty.
+func New(server, token string, opts ...ClientOption) (*ClientWithResponses, error) {
+	if token != "" {
+		opts = append(opts, WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		}))
+	}
+	return NewClientWithResponses(strings.TrimRight(server, "/"), opts...)
+}
+
+// ResponseError is error responded by API
+type ResponseError struct {
+	StatusCode int
+	Body       Error
+}
+
+func (e *ResponseError) Error() string {
+	if e.Body.Message == "" {
+		return fmt.Sprintf("API responded %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("API responded %d: %s", e.StatusCode, e.Body.Message)
+}
+
+// CheckResponse returns *ResponseError unless res has expected status
+func CheckResponse(res *http.Response, body []byte, expected int) error {
+	if res.StatusCode == expected {
+		return nil
+	}
+	e := &ResponseError{StatusCode: res.StatusCode}
+	_ = json.Unmarshal(body, &e.Body)
+	return e
+}
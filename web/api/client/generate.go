@@ -0,0 +1,3 @@
+package client
+
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen -generate types,client -package client -o client.gen.go ../openapi.yaml
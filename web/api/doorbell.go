@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"golang.org/x/xerrors"
+)
+
+// doorbell is view of unifi.Doorbell for API clients
+type doorbell struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Model string `json:"model"`
+	// HasPackageCamera is true if package snapshot is available
+	HasPackageCamera bool                   `json:"has_package_camera"`
+	Mac              string                 `json:"mac"`
+	Firmware         string                 `json:"firmware"`
+	State            string                 `json:"state"`
+	IsConnected      bool                   `json:"is_connected"`
+	ConnectedSince   int64                  `json:"connected_since,omitempty"`
+	Host             string                 `json:"host,omitempty"`
+	Wifi             *wifi                  `json:"wifi,omitempty"`
+	SpeakerVolume    int                    `json:"speaker_volume"`
+	Settings         unifi.DoorbellSettings `json:"settings"`
+	LcdMessage       *lcdMessage            `json:"lcd_message,omitempty"`
+	LastRing         uint64                 `json:"last_ring"`
+	LastMotion       int64                  `json:"last_motion"`
+}
+
+type wifi struct {
+	SignalStrength int `json:"signal_strength"`
+	SignalQuality  int `json:"signal_quality"`
+}
+
+type lcdMessage struct {
+	Type    string `json:"type"`
+	Text    string `json:"text"`
+	ResetAt *int64 `json:"reset_at,omitempty"`
+}
+
+func newDoorbell(d *unifi.Doorbell) doorbell {
+	v := doorbell{
+		ID:               d.ID,
+		Name:             d.Name,
+		Model:            d.Type,
+		HasPackageCamera: d.HasPackageCamera(),
+		Mac:              d.Mac,
+		Firmware:         d.FirmwareVersion,
+		State:            d.State,
+		IsConnected:      d.IsConnected,
+		ConnectedSince:   d.ConnectedSince,
+		Host:             d.Host,
+		SpeakerVolume:    d.SpeakerSettings.Volume,
+		Settings:         unifi.SettingsOf(d),
+		LastRing:         d.LastRing,
+		LastMotion:       d.LastMotion,
+	}
+	if d.HasWifi || d.FeatureFlags.HasWifi {
+		v.Wifi = &wifi{
+			SignalStrength: d.Stats.Wifi.SignalStrength,
+			SignalQuality:  d.Stats.Wifi.SignalQuality,
+		}
+	}
+	if d.LcdMessage.Type != "" || d.LcdMessage.Text != "" {
+		v.LcdMessage = &lcdMessage{
+			Type:    d.LcdMessage.Type,
+			Text:    d.LcdMessage.Text,
+			ResetAt: d.LcdMessage.ResetAt,
+		}
+	}
+	return v
+}
+
+func (s *Server) doorbellList(w http.ResponseWriter, r *http.Request) {
+	ds, err := s.r.UnifiClient().Cache().Doorbells(r.Context(), unifi.DefaultMaxAge)
+	if err != nil {
+		s.writeUpstreamError(w, err)
+		return
+	}
+
+	res := struct {
+		Doorbells []doorbell `json:"doorbells"`
+	}{
+		Doorbells: make([]doorbell, 0, len(ds)),
+	}
+	for i := range ds {
+		res.Doorbells = append(res.Doorbells, newDoorbell(&ds[i]))
+	}
+	s.writeJSON(w, http.StatusOK, &res)
+}
+
+func (s *Server) getDoorbell(w http.ResponseWriter, r *http.Request) {
+	doorbellID := mux.Vars(r)["doorbellID"]
+
+	d, err := s.r.UnifiClient().Cache().Doorbell(r.Context(), doorbellID, unifi.DefaultMaxAge)
+	if err != nil {
+		s.writeUpstreamError(w, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, newDoorbell(d))
+}
+
+func (s *Server) updateDoorbellSettings(w http.ResponseWriter, r *http.Request) {
+	doorbellID := mux.Vars(r)["doorbellID"]
+
+	var settings unifi.DoorbellSettings
+	defer func() {
+		if err := r.Body.Close(); err != nil {
+			s.logger.Error(err)
+		}
+	}()
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+		return
+	}
+
+	d, err := s.r.UnifiClient().UpdateDoorbellSettings(r.Context(), doorbellID, settings)
+	if err != nil {
+		var rejected unifi.SettingErrors
+		if xerrors.As(err, &rejected) {
+			writeError(w, http.StatusBadRequest, "%s", rejected)
+			return
+		}
+		s.writeUpstreamError(w, err)
+		return
+	}
+	s.logger.Infof("settings of %s are updated", d.Name)
+	s.writeJSON(w, http.StatusOK, newDoorbell(d))
+}
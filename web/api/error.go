@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"golang.org/x/xerrors"
+)
+
+// Error is body of error responses
+type Error struct {
+	// Code is status text in snake case such as not_found
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func errorCode(status int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
+
+// writeError responds status with Error
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&Error{
+		Code:    errorCode(status),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// writeUpstreamError responds error of UniFi Protect.
+// Status of UniFi Protect is not forwarded as it is because it is not about this API.
+func (s *Server) writeUpstreamError(w http.ResponseWriter, err error) {
+	var he *unifi.HttpError
+	if xerrors.As(err, &he) && he.Code() == http.StatusNotFound || xerrors.Is(err, unifi.ErrDoorbellNotFound) {
+		s.logger.Warn(err)
+		writeError(w, http.StatusNotFound, "doorbell is not found")
+		return
+	}
+
+	s.logger.Error(err)
+	writeError(w, http.StatusBadGateway, "failed to request UniFi Protect")
+}
+
+func notFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusNotFound, "%s is not found", r.URL.Path)
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusMethodNotAllowed, "%s is not allowed for %s", r.Method, r.URL.Path)
+}
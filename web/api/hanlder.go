@@ -7,44 +7,60 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/sawadashota/unifi-doorbell-chime/clip"
 	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
 	"golang.org/x/xerrors"
 )
 
+// writeJSON responds v as JSON with status
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		s.logger.Error(err)
+		writeError(w, http.StatusInternalServerError, "failed to encode response")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
+
 func (s *Server) messageTemplateList(w http.ResponseWriter, _ *http.Request) {
 	res := struct {
 		Templates []string `json:"templates"`
 	}{
 		Templates: s.c.MessageList(),
 	}
+	s.writeJSON(w, http.StatusOK, &res)
+}
 
+func (s *Server) getSnapshot(w http.ResponseWriter, r *http.Request) {
+	doorbellID := mux.Vars(r)["doorbellID"]
+
+	// snapshot is buffered to respond error instead of broken image
 	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(&res); err != nil {
-		s.logger.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+	if err := s.r.UnifiClient().GetSnapshot(r.Context(), &buf, doorbellID); err != nil {
+		s.writeUpstreamError(w, err)
 		return
 	}
+	w.Header().Set("Content-Type", "image/jpeg")
 	_, _ = w.Write(buf.Bytes())
 }
 
-func (s *Server) getSnapshot(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	doorbellID, ok := vars["doorbellID"]
-	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
+func (s *Server) getPackageSnapshot(w http.ResponseWriter, r *http.Request) {
+	doorbellID := mux.Vars(r)["doorbellID"]
 
-	if err := s.r.UnifiClient().GetSnapshot(r.Context(), w, doorbellID); err != nil {
-		if xerrors.Is(err, &unifi.HttpError{}) {
-			s.logger.Warn(err)
-			w.WriteHeader(err.(*unifi.HttpError).Code())
+	var buf bytes.Buffer
+	if err := s.r.UnifiClient().GetPackageSnapshot(r.Context(), &buf, doorbellID); err != nil {
+		if xerrors.Is(err, unifi.ErrNoPackageCamera) {
+			writeError(w, http.StatusNotFound, "doorbell has no package camera")
 			return
 		}
-
-		s.logger.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		s.writeUpstreamError(w, err)
+		return
 	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	_, _ = w.Write(buf.Bytes())
 }
 
 func (s *Server) setMessage(w http.ResponseWriter, r *http.Request) {
@@ -62,8 +78,7 @@ func (s *Server) setMessage(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 	if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
-		s.logger.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		writeError(w, http.StatusBadRequest, "invalid JSON: %s", err)
 		return
 	}
 
@@ -73,10 +88,94 @@ func (s *Server) setMessage(w http.ResponseWriter, r *http.Request) {
 		param.Message,
 		time.Duration(param.DurationSec)*time.Second,
 	); err != nil {
-		s.logger.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		s.writeUpstreamError(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusCreated)
 }
+
+func (s *Server) getLiveStream(w http.ResponseWriter, r *http.Request) {
+	doorbellID := mux.Vars(r)["doorbellID"]
+
+	ctx, cancel := streamContext(r)
+	defer cancel()
+
+	if err := s.r.StreamProxy().ServeMJPEG(ctx, w, doorbellID); err != nil {
+		if xerrors.Is(err, unifi.ErrRTSPDisabled) {
+			s.logger.Warn(err)
+			writeError(w, http.StatusNotFound, "live view is not available. enable RTSP of the doorbell")
+			return
+		}
+		s.writeUpstreamError(w, err)
+	}
+}
+
+func (s *Server) getClip(w http.ResponseWriter, r *http.Request) {
+	eventID := mux.Vars(r)["eventID"]
+
+	f, err := s.r.ClipExporter().Store().Open(eventID)
+	if err != nil {
+		switch {
+		case xerrors.Is(err, clip.ErrInvalidID):
+			writeError(w, http.StatusBadRequest, "invalid event ID")
+		case xerrors.Is(err, clip.ErrNotFound):
+			writeError(w, http.StatusNotFound, "clip of event %s is not found", eventID)
+		default:
+			s.logger.Error(err)
+			writeError(w, http.StatusInternalServerError, "failed to open clip")
+		}
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		s.logger.Error(err)
+		writeError(w, http.StatusInternalServerError, "failed to open clip")
+		return
+	}
+
+	w.Header().Set("Content-Type", "video/mp4")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
+
+func (s *Server) getEventSnapshot(w http.ResponseWriter, r *http.Request) {
+	eventID := mux.Vars(r)["eventID"]
+
+	f, err := s.r.ClipExporter().Store().OpenSnapshot(eventID)
+	if err != nil {
+		switch {
+		case xerrors.Is(err, clip.ErrInvalidID):
+			writeError(w, http.StatusBadRequest, "invalid event ID")
+		case xerrors.Is(err, clip.ErrSnapshotNotFound):
+			writeError(w, http.StatusNotFound, "snapshot of event %s is not found", eventID)
+		default:
+			s.logger.Error(err)
+			writeError(w, http.StatusInternalServerError, "failed to open snapshot")
+		}
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		s.logger.Error(err)
+		writeError(w, http.StatusInternalServerError, "failed to open snapshot")
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/jpeg")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
+
+func (s *Server) simulateRing(w http.ResponseWriter, r *http.Request) {
+	doorbellID := mux.Vars(r)["doorbellID"]
+
+	report, err := s.r.Listener().SimulateRing(r.Context(), doorbellID)
+	if err != nil {
+		s.writeUpstreamError(w, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, report)
+}
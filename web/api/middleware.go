@@ -1,23 +1,82 @@
 package api
 
-import "net/http"
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+)
 
 func (s *Server) allowCORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Headers", "*")
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		if origin := r.Header.Get("Origin"); origin != "" && s.isAllowedOrigin(r, origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, OPTIONS")
+			w.Header().Add("Vary", "Origin")
+		}
 		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
+			w.WriteHeader(http.StatusNoContent)
 			return
 		}
 		next.ServeHTTP(w, r)
 	})
 }
 
+// isAllowedOrigin reports whether origin is web server which r is sent to or listed in config
+func (s *Server) isAllowedOrigin(r *http.Request, origin string) bool {
+	for _, allowed := range s.c.APICORSAllowedOrigins() {
+		if origin == allowed {
+			return true
+		}
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if u.Host == r.Host {
+		// same origin. scheme may differ behind reverse proxy terminating TLS
+		return true
+	}
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+	return u.Scheme == s.r.TLS().Scheme() && u.Hostname() == host && u.Port() == strconv.Itoa(s.c.WebPort())
+}
+
 func (s *Server) requestLogging(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		s.logger.WithField("method", r.Method).WithField("path", r.URL.String()).Info()
+		s.logger.WithField("method", r.Method).WithField("path", r.URL.Path).Info()
 		next.ServeHTTP(w, r)
 	})
 }
+
+// deprecated tells clients that the route under prefix is replaced by the one under successor
+func (s *Server) deprecated(prefix, successor string) mux.MiddlewareFunc {
+	var warned sync.Map
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path := strings.TrimPrefix(r.URL.Path, prefix)
+			if route := mux.CurrentRoute(r); route != nil {
+				if tmpl, err := route.GetPathTemplate(); err == nil {
+					path = strings.TrimPrefix(tmpl, prefix)
+				}
+			}
+			if _, loaded := warned.LoadOrStore(path, true); !loaded {
+				s.logger.Warnf("%s is deprecated. use %s%s", path, successor, path)
+			}
+
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", fmt.Sprintf("<%s%s>; rel=\"successor-version\"", successor, strings.TrimPrefix(r.URL.Path, prefix)))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gorilla/mux"
+)
+
+// specYAML is OpenAPI specification of API. Client package is generated from it.
+//
+//go:embed openapi.yaml
+var specYAML []byte
+
+var spec *openapi3.T
+
+func init() {
+	var err error
+	spec, err = openapi3.NewLoader().LoadFromData(specYAML)
+	if err != nil {
+		panic(err)
+	}
+	if err := spec.Validate(context.Background()); err != nil {
+		panic(err)
+	}
+}
+
+// Spec returns OpenAPI specification of API served under basePath
+func Spec(basePath string) *openapi3.T {
+	doc := *spec
+	doc.Servers = openapi3.Servers{{URL: basePath + "/api" + Version}}
+	return &doc
+}
+
+func (s *Server) serveSpec(basePath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		b, err := json.Marshal(Spec(basePath))
+		if err != nil {
+			s.logger.Error(err)
+			writeError(w, http.StatusInternalServerError, "failed to encode specification")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	}
+}
+
+// validateRequest responds 400 to requests which don't match specification.
+// Routes are under prefix.
+func (s *Server) validateRequest(prefix string) mux.MiddlewareFunc {
+	options := &openapi3filter.Options{
+		// authentication is done by handlers to respond 401 and 403 distinctly
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := s.specRoute(r, prefix)
+			if route == nil {
+				s.logger.Warnf("%s %s is not in specification", r.Method, r.URL.Path)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if r.Header.Get("Content-Type") == "" && r.ContentLength != 0 {
+				// clients of older versions send JSON without Content-Type
+				r.Header.Set("Content-Type", "application/json")
+			}
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: mux.Vars(r),
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				writeError(w, http.StatusBadRequest, "%s", validationMessage(err))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// specRoute returns operation of specification which r is routed to
+func (s *Server) specRoute(r *http.Request, prefix string) *routers.Route {
+	current := mux.CurrentRoute(r)
+	if current == nil {
+		return nil
+	}
+	tmpl, err := current.GetPathTemplate()
+	if err != nil {
+		return nil
+	}
+
+	path := strings.TrimPrefix(tmpl, prefix)
+	item := spec.Paths.Find(path)
+	if item == nil {
+		return nil
+	}
+	op := item.GetOperation(r.Method)
+	if op == nil {
+		return nil
+	}
+	return &routers.Route{
+		Spec:      spec,
+		Path:      path,
+		PathItem:  item,
+		Method:    r.Method,
+		Operation: op,
+	}
+}
+
+// validationMessage returns reason of validation error without dump of schema
+func validationMessage(err error) string {
+	re, ok := err.(*openapi3filter.RequestError)
+	if !ok {
+		return err.Error()
+	}
+
+	reason := re.Reason
+	if se, ok := re.Err.(*openapi3.SchemaError); ok {
+		reason = se.Reason
+		if field := strings.Join(se.JSONPointer(), "."); field != "" {
+			reason = field + ": " + reason
+		}
+	} else if re.Err != nil {
+		reason = strings.TrimSpace(reason + " " + re.Err.Error())
+	}
+
+	switch {
+	case re.Parameter != nil:
+		return "parameter " + re.Parameter.Name + ": " + reason
+	case re.RequestBody != nil:
+		return "request body: " + reason
+	default:
+		return reason
+	}
+}
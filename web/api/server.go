@@ -2,55 +2,160 @@ package api
 
 import (
 	"context"
-	"fmt"
+	"net"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/sawadashota/unifi-doorbell-chime/chime"
+	"github.com/sawadashota/unifi-doorbell-chime/clip"
+	"github.com/sawadashota/unifi-doorbell-chime/event"
+	"github.com/sawadashota/unifi-doorbell-chime/listener"
+	"github.com/sawadashota/unifi-doorbell-chime/x/apiauth"
+	"github.com/sawadashota/unifi-doorbell-chime/x/stream"
+	"github.com/sawadashota/unifi-doorbell-chime/x/talkback"
+	"github.com/sawadashota/unifi-doorbell-chime/x/tlsconfig"
 	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/xerrors"
 )
 
 type Server struct {
-	r      Registry
-	c      Configuration
-	logger logrus.FieldLogger
+	r        Registry
+	c        Configuration
+	logger   logrus.FieldLogger
+	events   *event.Hub
+	sessions *apiauth.SessionStore
 }
 
 type Registry interface {
 	AppLogger(app string) logrus.FieldLogger
 	UnifiClient() *unifi.Client
+	TLS() *tlsconfig.Manager
+	EventHub() *event.Hub
+	StreamProxy() *stream.Proxy
+	Talkback() *talkback.Talkback
+	ClipExporter() *clip.Exporter
+	Chime() *chime.Controller
+	Listener() *listener.Listener
 }
 
 type Configuration interface {
 	APIPort() int
+	APIBind() string
+	WebPort() int
 	MessageList() []string
+	ShutdownTimeoutSec() int
+
+	APIAuthEnabled() bool
+	APIAuthTrustLoopback() bool
+	APIAuthTrustedProxies() []string
+	APITokens() []apiauth.Token
+	APIUsers() []apiauth.User
+	APISessionTTLSec() int
+	APICORSAllowedOrigins() []string
 }
 
 func New(r Registry, c Configuration) *Server {
 	return &Server{
-		r:      r,
-		c:      c,
-		logger: r.AppLogger("api"),
+		r:        r,
+		c:        c,
+		logger:   r.AppLogger("api"),
+		events:   r.EventHub(),
+		sessions: apiauth.NewSessionStore(),
 	}
 }
 
+// Version is path prefix of current API
+const Version = "/v1"
+
+// Register adds API routes to m. Routes are under prefix.
+func (s *Server) Register(m *mux.Router, prefix string) {
+	m.Use(s.requestLogging, s.validateRequest(prefix))
+	m.HandleFunc("/auth/login", s.login).Methods(http.MethodPost)
+	m.HandleFunc("/auth/logout", s.logout).Methods(http.MethodPost)
+	m.HandleFunc("/auth/session", s.session).Methods(http.MethodGet)
+	m.HandleFunc("/snapshot/{doorbellID}", s.require(apiauth.ScopeRead, s.getSnapshot)).Methods(http.MethodGet)
+	m.HandleFunc("/snapshot/{doorbellID}/package", s.require(apiauth.ScopeRead, s.getPackageSnapshot)).Methods(http.MethodGet)
+	m.HandleFunc("/message/set", s.require(apiauth.ScopeMessage, s.setMessage)).Methods(http.MethodPost)
+	m.HandleFunc("/message/templates", s.require(apiauth.ScopeRead, s.messageTemplateList)).Methods(http.MethodGet)
+	m.HandleFunc("/stream/{doorbellID}", s.require(apiauth.ScopeRead, s.getLiveStream)).Methods(http.MethodGet)
+	m.HandleFunc("/talkback/{doorbellID}", s.require(apiauth.ScopeMessage, s.talkback)).Methods(http.MethodGet)
+	m.HandleFunc("/doorbells", s.require(apiauth.ScopeRead, s.doorbellList)).Methods(http.MethodGet)
+	m.HandleFunc("/doorbells/{doorbellID}", s.require(apiauth.ScopeRead, s.getDoorbell)).Methods(http.MethodGet)
+	m.HandleFunc("/doorbells/{doorbellID}/settings", s.require(apiauth.ScopeSettings, s.updateDoorbellSettings)).Methods(http.MethodPatch)
+	m.HandleFunc("/doorbells/{doorbellID}/chime", s.require(apiauth.ScopeRead, s.getChime)).Methods(http.MethodGet)
+	m.HandleFunc("/doorbells/{doorbellID}/chime", s.require(apiauth.ScopeSettings, s.updateChime)).Methods(http.MethodPatch)
+	m.HandleFunc("/doorbells/{doorbellID}/chime/test", s.require(apiauth.ScopeSettings, s.testChime)).Methods(http.MethodPost)
+	m.HandleFunc("/events/{eventID}/clip", s.require(apiauth.ScopeRead, s.getClip)).Methods(http.MethodGet)
+	m.HandleFunc("/events/{eventID}/snapshot", s.require(apiauth.ScopeRead, s.getEventSnapshot)).Methods(http.MethodGet)
+	m.HandleFunc("/events/stream", s.require(apiauth.ScopeRead, s.streamEvents)).Methods(http.MethodGet)
+	m.HandleFunc("/events/ws", s.require(apiauth.ScopeRead, s.streamEventsWebSocket)).Methods(http.MethodGet)
+	m.HandleFunc("/debug/ring/{doorbellID}", s.require(apiauth.ScopeAdmin, s.simulateRing)).Methods(http.MethodPost)
+}
+
+// RegisterDeprecated adds routes without version prefix to m for clients of older versions.
+// Only routes which existed before versioning are added. They are under prefix
+// and replaced by the ones under successor.
+func (s *Server) RegisterDeprecated(m *mux.Router, prefix, successor string) {
+	m.Use(s.requestLogging, s.validateRequest(prefix), s.deprecated(prefix, successor))
+	m.HandleFunc("/snapshot/{doorbellID}", s.require(apiauth.ScopeRead, s.getSnapshot)).Methods(http.MethodGet)
+	m.HandleFunc("/message/set", s.require(apiauth.ScopeMessage, s.setMessage)).Methods(http.MethodPost)
+	m.HandleFunc("/message/templates", s.require(apiauth.ScopeRead, s.messageTemplateList)).Methods(http.MethodGet)
+}
+
+// Mount adds API under /api/v1 of base, its specification at /api/openapi.json
+// and deprecated routes to base served under basePath
+func (s *Server) Mount(base *mux.Router, basePath string) {
+	m := base.PathPrefix("/api").Subrouter()
+	// don't fall back to frontend
+	m.NotFoundHandler = http.HandlerFunc(notFound)
+	m.MethodNotAllowedHandler = http.HandlerFunc(methodNotAllowed)
+	m.HandleFunc("/openapi.json", s.serveSpec(basePath)).Methods(http.MethodGet)
+	s.Register(m.PathPrefix(Version).Subrouter(), basePath+"/api"+Version)
+
+	s.RegisterDeprecated(base.NewRoute().Subrouter(), basePath, basePath+"/api"+Version)
+}
+
+// Handler returns handler of API. CORS is handled here as well.
+func (s *Server) Handler(next http.Handler) http.Handler {
+	// outside of router to answer preflight requests which match no route by method
+	return s.allowCORS(next)
+}
+
+// Start serves deprecated standalone API server on api.port if it is set.
+// Otherwise it waits until ctx is done because API is served by web server.
 func (s *Server) Start(ctx context.Context) error {
+	if s.c.APIPort() == 0 {
+		<-ctx.Done()
+		return nil
+	}
+
 	m := mux.NewRouter()
-	m.Use(s.allowCORS)
-	m.Use(s.requestLogging)
-	m.HandleFunc("/snapshot/{doorbellID}", s.getSnapshot).Methods(http.MethodGet)
-	m.HandleFunc("/message/set", s.setMessage).Methods(http.MethodPost)
-	m.HandleFunc("/message/templates", s.messageTemplateList).Methods(http.MethodGet)
+	m.NotFoundHandler = http.HandlerFunc(notFound)
+	m.MethodNotAllowedHandler = http.HandlerFunc(methodNotAllowed)
+	s.RegisterDeprecated(m, "", "/api"+Version)
+	addr := net.JoinHostPort(s.c.APIBind(), strconv.Itoa(s.c.APIPort()))
 	svr := &http.Server{
-		Addr:    fmt.Sprintf(":%d", s.c.APIPort()),
-		Handler: m,
+		Addr:    addr,
+		Handler: s.r.TLS().HSTS(s.Handler(m)),
+	}
+
+	// requests still in flight after shutdown are cancelled
+	abort := Graceful(svr)
+	defer abort()
+
+	tlsConfig, err := s.r.TLS().ServerConfig()
+	if err != nil {
+		return xerrors.Errorf("failed to configure TLS: %w", err)
 	}
+	svr.TLSConfig = tlsConfig
 
 	errCh := make(chan error, 1)
 	go func() {
-		s.logger.Infof("start API server. 127.0.0.1:%d", s.c.APIPort())
-		if err := svr.ListenAndServe(); err != nil {
+		s.logger.Warnf("api.port is deprecated. API is served on web.port under /api%s. start deprecated API server. %s://%s", Version, s.r.TLS().Scheme(), addr)
+		if err := tlsconfig.ListenAndServe(svr); err != nil {
 			errCh <- err
 		}
 	}()
@@ -58,7 +163,10 @@ func (s *Server) Start(ctx context.Context) error {
 	select {
 	case <-ctx.Done():
 		s.logger.Info("Bye!")
-		return svr.Shutdown(ctx)
+		// give in-flight requests time to finish and cancel the rest
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(s.c.ShutdownTimeoutSec())*time.Second)
+		defer cancel()
+		return svr.Shutdown(shutdownCtx)
 	case err := <-errCh:
 		s.logger.Debugf("%+v", err)
 		return xerrors.Errorf("exit api server: %w", err)
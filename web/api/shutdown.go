@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+)
+
+type closingKey struct{}
+
+// Graceful prepares svr to shut down gracefully and returns function to abort requests.
+// Requests are not cancelled when Shutdown is called so that they can finish in time,
+// except for streaming requests which never finish by themselves and are closed right away.
+// abort cancels requests still in flight and is called after Shutdown returns.
+func Graceful(svr *http.Server) (abort func()) {
+	base, abort := context.WithCancel(context.Background())
+
+	closing := make(chan struct{})
+	var once sync.Once
+	// hijacked WebSocket connections are not tracked by Shutdown, so they are notified here as well
+	svr.RegisterOnShutdown(func() {
+		once.Do(func() {
+			close(closing)
+		})
+	})
+	svr.BaseContext = func(net.Listener) context.Context {
+		return context.WithValue(base, closingKey{}, (<-chan struct{})(closing))
+	}
+	return abort
+}
+
+// streamContext returns context of streaming request which is cancelled
+// when the request is done or the server starts shutting down
+func streamContext(r *http.Request) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(r.Context())
+	if closing, ok := ctx.Value(closingKey{}).(<-chan struct{}); ok {
+		go func() {
+			select {
+			case <-closing:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+	return ctx, cancel
+}
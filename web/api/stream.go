@@ -0,0 +1,136 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const streamHeartbeatInterval = 15 * time.Second
+
+// streamEvents pushes events as Server-Sent Events
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.logger.Error("streaming is not supported by response writer")
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	ctx, cancel := streamContext(r)
+	defer cancel()
+
+	events, unsubscribe := s.events.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				s.logger.Debug(err)
+				return
+			}
+			flusher.Flush()
+
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			var buf bytes.Buffer
+			if err := json.NewEncoder(&buf).Encode(&e); err != nil {
+				s.logger.Error(err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n", e.ID, e.Type, buf.Bytes()); err != nil {
+				s.logger.Debug(err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// upgrader accepts WebSocket only from allowed origins because browsers don't apply CORS to WebSocket
+func (s *Server) upgrader() *websocket.Upgrader {
+	return &websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			return origin == "" || s.isAllowedOrigin(r, origin)
+		},
+	}
+}
+
+// streamEventsWebSocket pushes events as WebSocket text messages
+func (s *Server) streamEventsWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader().Upgrade(w, r, nil)
+	if err != nil {
+		s.logger.Warn(err)
+		return
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			s.logger.Debug(err)
+		}
+	}()
+
+	ctx, cancel := streamContext(r)
+	defer cancel()
+
+	events, unsubscribe := s.events.Subscribe()
+	defer unsubscribe()
+
+	// read messages only to detect closing by client
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-closed:
+			return
+
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+				s.logger.Debug(err)
+				return
+			}
+
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(&e); err != nil {
+				s.logger.Debug(err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"golang.org/x/xerrors"
+)
+
+const defaultTalkbackInputRate = 48000
+
+// talkback receives microphone audio of browser as binary WebSocket messages
+// of mono signed 16-bit little endian PCM and forwards it to the doorbell speaker.
+func (s *Server) talkback(w http.ResponseWriter, r *http.Request) {
+	doorbellID := mux.Vars(r)["doorbellID"]
+
+	rate := defaultTalkbackInputRate
+	if v := r.URL.Query().Get("rate"); v != "" {
+		var err error
+		if rate, err = strconv.Atoi(v); err != nil || rate <= 0 {
+			writeError(w, http.StatusBadRequest, "rate must be positive integer")
+			return
+		}
+	}
+
+	ctx, cancel := streamContext(r)
+	defer cancel()
+
+	session, err := s.r.Talkback().Open(ctx, doorbellID, rate)
+	if err != nil {
+		if xerrors.Is(err, unifi.ErrTalkbackUnsupported) {
+			s.logger.Warn(err)
+			writeError(w, http.StatusNotFound, "talkback is not supported by the doorbell")
+			return
+		}
+		s.writeUpstreamError(w, err)
+		return
+	}
+	defer func() {
+		if err := session.Close(); err != nil {
+			s.logger.Error(err)
+		}
+	}()
+
+	conn, err := s.upgrader().Upgrade(w, r, nil)
+	if err != nil {
+		s.logger.Warn(err)
+		return
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			s.logger.Debug(err)
+		}
+	}()
+	// unblock reading on shutdown
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	for {
+		mt, b, err := conn.ReadMessage()
+		if err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				s.logger.Debug(err)
+			}
+			return
+		}
+		if mt != websocket.BinaryMessage {
+			continue
+		}
+		if _, err := session.Write(b); err != nil {
+			s.logger.Error(err)
+			return
+		}
+	}
+}
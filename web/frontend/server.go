@@ -2,10 +2,10 @@ package frontend
 
 import (
 	"bytes"
-	"context"
 	"embed"
 	"encoding/json"
 	"fmt"
+	"html"
 	"io/fs"
 	"net/http"
 	"path/filepath"
@@ -13,7 +13,6 @@ import (
 
 	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
 	"github.com/sirupsen/logrus"
-	"golang.org/x/xerrors"
 )
 
 var (
@@ -72,9 +71,8 @@ type Registry interface {
 }
 
 type Configuration interface {
-	WebPort() int
-	APIPort() int
-	MessageList() []string
+	WebBasePath() string
+	DashboardSnapshotIntervalSec() int
 }
 
 func New(r Registry, c Configuration) *Server {
@@ -88,9 +86,12 @@ func New(r Registry, c Configuration) *Server {
 
 func (s *Server) handleWellKnownConfiguration(w http.ResponseWriter, _ *http.Request) {
 	res := struct {
-		APIEndpoint string `json:"api_endpoint"`
+		APIEndpoint                  string `json:"api_endpoint"`
+		DashboardSnapshotIntervalSec int    `json:"dashboard_snapshot_interval_sec"`
 	}{
-		APIEndpoint: fmt.Sprintf("http://127.0.0.1:%d", s.c.APIPort()),
+		// path on the same origin works behind reverse proxy
+		APIEndpoint:                  s.c.WebBasePath() + "/api/v1",
+		DashboardSnapshotIntervalSec: s.c.DashboardSnapshotIntervalSec(),
 	}
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(&res); err != nil {
@@ -100,6 +101,23 @@ func (s *Server) handleWellKnownConfiguration(w http.ResponseWriter, _ *http.Req
 	_, _ = w.Write(buf.Bytes())
 }
 
+// index returns index.html referring assets under base path
+func (s *Server) index() ([]byte, error) {
+	b, err := fs.ReadFile(public, "index.html")
+	if err != nil {
+		return nil, err
+	}
+
+	basePath := s.c.WebBasePath()
+	meta := fmt.Sprintf(`<head>
+    <meta name="base-path" content="%s" />`, html.EscapeString(basePath))
+	b = bytes.Replace(b, []byte("<head>"), []byte(meta), 1)
+	if basePath != "" {
+		b = bytes.ReplaceAll(b, []byte(`="/`), []byte(`="`+basePath+`/`))
+	}
+	return b, nil
+}
+
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if r.URL.Path == "/.well-known/configuration" {
 		s.handleWellKnownConfiguration(w, r)
@@ -112,7 +130,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if filepath.Ext(r.URL.Path) == "html" || filepath.Ext(r.URL.Path) == "" {
-		if b, err := fs.ReadFile(public, "index.html"); err == nil {
+		if b, err := s.index(); err == nil {
 			w.Header().Add("Content-Type", "text/html")
 			_, _ = w.Write(b)
 			return
@@ -122,27 +140,3 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.logger.Warnf("%s is not found", r.URL.Path)
 	w.WriteHeader(http.StatusNotFound)
 }
-
-func (s *Server) Start(ctx context.Context) error {
-	svr := &http.Server{
-		Addr:    fmt.Sprintf(":%d", s.c.WebPort()),
-		Handler: s,
-	}
-
-	errCh := make(chan error, 1)
-	go func() {
-		s.logger.Infof("start frontend server. 127.0.0.1:%d", s.c.WebPort())
-		if err := svr.ListenAndServe(); err != nil {
-			errCh <- err
-		}
-	}()
-
-	select {
-	case <-ctx.Done():
-		s.logger.Info("Bye!")
-		return svr.Shutdown(ctx)
-	case err := <-errCh:
-		s.logger.Debugf("%+v", err)
-		return xerrors.Errorf("exit frontend server: %w", err)
-	}
-}
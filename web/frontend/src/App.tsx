@@ -3,13 +3,24 @@ import { h } from 'preact';
 import Router from 'preact-router';
 import AsyncRoute from 'preact-async-route';
 import type { FunctionComponent } from 'preact';
+import { basePath } from './adapter/basePath';
 
 const App: FunctionComponent = () => (
   <Router>
     <AsyncRoute
-      path="/ringing/:doorbell_id"
+      path={`${basePath}/ringing/:doorbell_id`}
       getComponent={() => import('./Ringing').then((module) => module.Ringing)}
     />
+    <AsyncRoute
+      path={`${basePath}/dashboard`}
+      getComponent={() =>
+        import('./Dashboard').then((module) => module.Dashboard)
+      }
+    />
+    <AsyncRoute
+      path={`${basePath}/login`}
+      getComponent={() => import('./Login').then((module) => module.Login)}
+    />
   </Router>
 );
 
@@ -0,0 +1,148 @@
+import React, { useEffect, useState } from 'preact/compat';
+// @ts-ignore
+import { h, JSX } from 'preact';
+import type { FunctionComponent } from 'preact';
+import './Dashboard.css';
+import { Ringing } from './Ringing';
+import { Client, Doorbell, DoorbellEvent } from './adapter/Client';
+
+const ringingViewTimeoutMs = 2 * 60 * 1000;
+
+const formatLastRing = (lastRing: number): string => {
+  if (lastRing === 0) {
+    return 'never';
+  }
+  return new Date(lastRing).toLocaleString();
+};
+
+const Dashboard: FunctionComponent = () => {
+  const [client, setClient] = useState<Client | null>(null);
+  const [doorbells, setDoorbells] = useState<Doorbell[]>([]);
+  const [tick, setTick] = useState<number>(Date.now());
+  const [ringing, setRinging] = useState<DoorbellEvent | null>(null);
+  const [healthy, setHealthy] = useState<boolean>(true);
+  const [parcel, setParcel] = useState<DoorbellEvent | null>(null);
+
+  const refreshDoorbells = async (cl: Client): Promise<void> => {
+    const res = await cl.doorbells();
+    setDoorbells(res.doorbells);
+  };
+
+  useEffect(() => {
+    Client.configure()
+      .then((cl) => {
+        setClient(cl);
+        return refreshDoorbells(cl);
+      })
+      .catch(console.error);
+  }, []);
+
+  useEffect(() => {
+    if (client === null) {
+      return;
+    }
+    const timer = setInterval(
+      () => setTick(Date.now()),
+      client.dashboardSnapshotIntervalSec * 1000,
+    );
+    return () => clearInterval(timer);
+  }, [client]);
+
+  useEffect(() => {
+    if (client === null) {
+      return;
+    }
+    return client.subscribeEvents((e: DoorbellEvent) => {
+      switch (e.type) {
+        case 'ring':
+          setRinging(e);
+          refreshDoorbells(client).catch(console.error);
+          break;
+        case 'health':
+          setHealthy(e.status === 'up');
+          break;
+        case 'message':
+          refreshDoorbells(client).catch(console.error);
+          break;
+        case 'package':
+          setParcel(e);
+          // show package camera right away
+          setTick(Date.now());
+          break;
+      }
+    });
+  }, [client]);
+
+  useEffect(() => {
+    if (ringing === null) {
+      return;
+    }
+    const timer = setTimeout(() => setRinging(null), ringingViewTimeoutMs);
+    return () => clearTimeout(timer);
+  }, [ringing]);
+
+  if (ringing !== null && ringing.doorbell_id !== undefined) {
+    return (
+      <div className="Dashboard__ringing">
+        <Ringing key={ringing.id} doorbell_id={ringing.doorbell_id} />
+        <div className="Dashboard__close">
+          <button
+            className="button gray block"
+            onClick={() => setRinging(null)}
+          >
+            Back To Dashboard
+          </button>
+        </div>
+      </div>
+    );
+  }
+
+  const cards = doorbells.map(
+    (d: Doorbell): JSX.Element => (
+      <div key={d.id} className="Dashboard__card">
+        <h2 className="Dashboard__name">{d.name}</h2>
+        {client !== null && (
+          <img
+            className="Dashboard__snapshot"
+            src={`${client.snapshotURL(d.id)}?t=${tick}`}
+            alt={d.name}
+          />
+        )}
+        {client !== null && d.has_package_camera && (
+          <img
+            className="Dashboard__snapshot"
+            src={`${client.packageSnapshotURL(d.id)}?t=${tick}`}
+            alt={`Package camera of ${d.name}`}
+          />
+        )}
+        <dl className="Dashboard__status">
+          <dt>Status</dt>
+          <dd>{d.is_connected ? d.state : 'DISCONNECTED'}</dd>
+          <dt>Model</dt>
+          <dd>{d.model}</dd>
+          {d.wifi !== undefined && <dt>Wi-Fi</dt>}
+          {d.wifi !== undefined && <dd>{d.wifi.signal_strength} dBm</dd>}
+          <dt>Last Ring</dt>
+          <dd>{formatLastRing(d.last_ring)}</dd>
+        </dl>
+      </div>
+    ),
+  );
+
+  return (
+    <div className="Dashboard">
+      {!healthy && (
+        <p className="Dashboard__alert">Connection to UniFi Protect is lost</p>
+      )}
+      {parcel !== null && (
+        <p className="Dashboard__package">
+          Package {parcel.package === 'picked_up' ? 'picked up' : 'delivered'}{' '}
+          at {parcel.doorbell_name} ({new Date(parcel.time).toLocaleTimeString()})
+        </p>
+      )}
+      <div className="Dashboard__cards">{cards}</div>
+    </div>
+  );
+};
+
+export { Dashboard };
@@ -0,0 +1,67 @@
+import React, { useState } from 'preact/compat';
+// @ts-ignore
+import { h, JSX } from 'preact';
+import type { FunctionComponent } from 'preact';
+import './Login.css';
+import { Client } from './adapter/Client';
+import { basePath } from './adapter/basePath';
+
+const nextPath = (): string => {
+  const next = new URLSearchParams(window.location.search).get('next');
+  // accept only local path not to be used as open redirect
+  if (next === null || !next.startsWith('/') || next.startsWith('//')) {
+    return `${basePath}/dashboard`;
+  }
+  return next;
+};
+
+const Login: FunctionComponent = () => {
+  const [username, setUsername] = useState<string>('');
+  const [password, setPassword] = useState<string>('');
+  const [failed, setFailed] = useState<boolean>(false);
+
+  const onSubmit = async (e: Event): Promise<void> => {
+    e.preventDefault();
+    const cl = await Client.configure();
+    if (await cl.login(username, password)) {
+      window.location.href = nextPath();
+      return;
+    }
+    setFailed(true);
+  };
+
+  return (
+    <form
+      className="Login"
+      onSubmit={(e: Event) => {
+        onSubmit(e).catch(console.error);
+      }}
+    >
+      <h1 className="Login__title">Log in</h1>
+      {failed && (
+        <p className="Login__error">Username or password is wrong</p>
+      )}
+      <input
+        className="Login__input"
+        type="text"
+        placeholder="Username"
+        autoComplete="username"
+        value={username}
+        onInput={(e) => setUsername((e.target as HTMLInputElement).value)}
+      />
+      <input
+        className="Login__input"
+        type="password"
+        placeholder="Password"
+        autoComplete="current-password"
+        value={password}
+        onInput={(e) => setPassword((e.target as HTMLInputElement).value)}
+      />
+      <button className="button blue block" type="submit">
+        Log in
+      </button>
+    </form>
+  );
+};
+
+export { Login };
@@ -4,6 +4,7 @@ import { h, JSX } from 'preact';
 import type { FunctionComponent } from 'preact';
 import './Ringing.css';
 import { Client } from './adapter/Client';
+import { Talkback } from './adapter/Talkback';
 
 interface Prop {
   doorbell_id: string;
@@ -13,15 +14,30 @@ const Ringing: FunctionComponent<Prop> = ({ doorbell_id }) => {
   const [image, setImage] = useState<JSX.Element>(<p>loading snapshot...</p>);
   const [selected, select] = useState<string>('');
   const [templates, setTemplates] = useState<JSX.Element[]>([]);
+  const [talkback, setTalkback] = useState<Talkback | null>(null);
+  const [talking, setTalking] = useState<boolean>(false);
+  const [name, setName] = useState<string>('');
   const noReactionText = 'No Reaction';
 
   const getTemplates = async (): Promise<void> => {
     const cl = await Client.configure();
 
+    // show live view and fall back to still snapshot if streaming is not available
+    const fallback = () =>
+      setImage(<img src={cl.snapshotURL(doorbell_id)} alt="Snapshot" />);
     setImage(
-      <img src={`${cl.apiEndpoint}/snapshot/${doorbell_id}`} alt="Snapshot" />,
+      <img
+        src={cl.streamURL(doorbell_id)}
+        alt="Live View"
+        onError={fallback}
+      />,
     );
 
+    setTalkback(new Talkback(cl.apiEndpoint, doorbell_id));
+    cl.doorbell(doorbell_id)
+      .then((d) => setName(d.name))
+      .catch(console.error);
+
     const mt = await cl.messageTemplates();
     const els = mt.templates.map(
       (t: string, i: number): JSX.Element => {
@@ -45,6 +61,26 @@ const Ringing: FunctionComponent<Prop> = ({ doorbell_id }) => {
     }
   }, []);
 
+  const startTalking = () => {
+    if (talkback === null || talking) {
+      return;
+    }
+    setTalking(true);
+    talkback.start().catch((e) => {
+      console.error(e);
+      talkback.stop();
+      setTalking(false);
+    });
+  };
+
+  const stopTalking = () => {
+    if (talkback === null || !talking) {
+      return;
+    }
+    talkback.stop();
+    setTalking(false);
+  };
+
   const actions = () => {
     if (selected === '') {
       return (
@@ -74,8 +110,23 @@ const Ringing: FunctionComponent<Prop> = ({ doorbell_id }) => {
   };
   return (
     <div className="Ringing">
-      <h1 className="Ringing__title">Someone At The Door</h1>
+      <h1 className="Ringing__title">
+        {name === '' ? 'Someone At The Door' : `Someone At ${name}`}
+      </h1>
       <div className="Ringing__snapshot">{image}</div>
+      <div className="Ringing__talkback">
+        <button
+          className={`button gray block ${talking ? 'active' : ''}`}
+          disabled={talkback === null}
+          onMouseDown={startTalking}
+          onMouseUp={stopTalking}
+          onMouseLeave={stopTalking}
+          onTouchStart={startTalking}
+          onTouchEnd={stopTalking}
+        >
+          {talking ? 'Talking...' : 'Hold To Talk'}
+        </button>
+      </div>
       {actions()}
     </div>
   );
@@ -1,32 +1,174 @@
+import { basePath } from './basePath';
+
 interface Configuration {
   api_endpoint: string;
+  dashboard_snapshot_interval_sec: number;
 }
 
 export interface MessageTemplates {
   templates: string[];
 }
 
+export interface Doorbell {
+  id: string;
+  name: string;
+  model: string;
+  has_package_camera: boolean;
+  mac: string;
+  firmware: string;
+  state: string;
+  is_connected: boolean;
+  connected_since?: number;
+  host?: string;
+  wifi?: {
+    signal_strength: number;
+    signal_quality: number;
+  };
+  speaker_volume: number;
+  lcd_message?: {
+    type: string;
+    text: string;
+    reset_at?: number;
+  };
+  last_ring: number;
+  last_motion: number;
+}
+
+export interface Doorbells {
+  doorbells: Doorbell[];
+}
+
+export type EventType = 'ring' | 'motion' | 'health' | 'message' | 'package';
+
+export interface DoorbellEvent {
+  id: string;
+  type: EventType;
+  time: string;
+  doorbell_id?: string;
+  doorbell_name?: string;
+  message?: string;
+  package?: 'delivered' | 'picked_up';
+  status?: 'up' | 'down';
+  error?: string;
+}
+
+export interface Session {
+  auth_enabled: boolean;
+  authenticated: boolean;
+  name?: string;
+  scopes: string[];
+}
+
+// redirectToLogin sends user to login page and comes back to current page after login
+const redirectToLogin = (): void => {
+  const next = encodeURIComponent(window.location.pathname);
+  window.location.href = `${basePath}/login?next=${next}`;
+};
+
 export class Client {
   public readonly apiEndpoint: string;
+  public readonly dashboardSnapshotIntervalSec: number;
 
-  constructor(api_endpoint: string) {
+  constructor(api_endpoint: string, dashboard_snapshot_interval_sec = 10) {
     this.apiEndpoint = api_endpoint;
+    this.dashboardSnapshotIntervalSec = dashboard_snapshot_interval_sec;
   }
 
   public static async configure(): Promise<Client> {
-    const res = await fetch('/.well-known/configuration');
+    const res = await fetch(`${basePath}/.well-known/configuration`);
     if (res.status !== 200) {
       throw Error('failed to get configuration');
     }
     const config = (await res.json()) as Configuration;
 
-    return new Client(config.api_endpoint);
+    return new Client(
+      config.api_endpoint,
+      config.dashboard_snapshot_interval_sec,
+    );
   }
 
-  public async messageTemplates(): Promise<MessageTemplates> {
-    const res = await fetch(`${this.apiEndpoint}/message/templates`, {
+  public snapshotURL(doorbell_id: string): string {
+    return `${this.apiEndpoint}/snapshot/${doorbell_id}`;
+  }
+
+  public packageSnapshotURL(doorbell_id: string): string {
+    return `${this.apiEndpoint}/snapshot/${doorbell_id}/package`;
+  }
+
+  public streamURL(doorbell_id: string): string {
+    return `${this.apiEndpoint}/stream/${doorbell_id}`;
+  }
+
+  // request sends session cookie to API server and redirects to login page if it is required
+  private async request(
+    path: string,
+    init: RequestInit = {},
+  ): Promise<Response> {
+    const res = await fetch(`${this.apiEndpoint}${path}`, {
+      ...init,
+      mode: 'cors',
+      credentials: 'include',
+    });
+    if (res.status === 401) {
+      redirectToLogin();
+    }
+    return res;
+  }
+
+  public async session(): Promise<Session> {
+    const res = await this.request('/auth/session');
+    if (res.status !== 200) {
+      throw Error('failed to get session');
+    }
+    return (await res.json()) as Session;
+  }
+
+  public async login(username: string, password: string): Promise<boolean> {
+    const res = await fetch(`${this.apiEndpoint}/auth/login`, {
+      method: 'POST',
       mode: 'cors',
+      credentials: 'include',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({ username, password }),
     });
+    return res.status === 204;
+  }
+
+  public async doorbells(): Promise<Doorbells> {
+    const res = await this.request('/doorbells');
+    if (res.status !== 200) {
+      throw Error('failed to get doorbells');
+    }
+
+    return (await res.json()) as Doorbells;
+  }
+
+  public async doorbell(id: string): Promise<Doorbell> {
+    const res = await this.request(`/doorbells/${encodeURIComponent(id)}`);
+    if (res.status !== 200) {
+      throw Error('failed to get doorbell');
+    }
+
+    return (await res.json()) as Doorbell;
+  }
+
+  public subscribeEvents(
+    onEvent: (event: DoorbellEvent) => void,
+  ): () => void {
+    const source = new EventSource(`${this.apiEndpoint}/events/stream`, {
+      withCredentials: true,
+    });
+    const listener = (e: Event) => {
+      onEvent(JSON.parse((e as MessageEvent).data) as DoorbellEvent);
+    };
+    const types: EventType[] = ['ring', 'motion', 'health', 'message'];
+    types.forEach((t) => source.addEventListener(t, listener));
+
+    return () => source.close();
+  }
+
+  public async messageTemplates(): Promise<MessageTemplates> {
+    const res = await this.request('/message/templates');
     if (res.status !== 200) {
       throw Error('failed to get message templates');
     }
@@ -35,9 +177,9 @@ export class Client {
   }
 
   public async setMessage(doorbell_id: string, message: string): Promise<void> {
-    const res = await fetch(`${this.apiEndpoint}/message/set`, {
+    const res = await this.request('/message/set', {
       method: 'POST',
-      mode: 'cors',
+      headers: { 'Content-Type': 'application/json' },
       body: JSON.stringify({
         doorbell_id,
         message,
@@ -0,0 +1,63 @@
+const bufferSize = 4096;
+
+// Talkback streams microphone audio to doorbell speaker through API server
+// as mono signed 16-bit little endian PCM.
+export class Talkback {
+  private readonly url: string;
+  private socket: WebSocket | null = null;
+  private context: AudioContext | null = null;
+  private stream: MediaStream | null = null;
+  private processor: ScriptProcessorNode | null = null;
+
+  constructor(api_endpoint: string, doorbell_id: string) {
+    // API endpoint may be path on the same origin
+    const url = new URL(
+      `${api_endpoint}/talkback/${doorbell_id}`,
+      window.location.href,
+    );
+    url.protocol = url.protocol.replace(/^http/, 'ws');
+    this.url = url.toString();
+  }
+
+  public async start(): Promise<void> {
+    this.stream = await navigator.mediaDevices.getUserMedia({ audio: true });
+    this.context = new AudioContext();
+
+    const socket = new WebSocket(`${this.url}?rate=${this.context.sampleRate}`);
+    socket.binaryType = 'arraybuffer';
+    this.socket = socket;
+
+    const source = this.context.createMediaStreamSource(this.stream);
+    this.processor = this.context.createScriptProcessor(bufferSize, 1, 1);
+    this.processor.onaudioprocess = (e: AudioProcessingEvent) => {
+      if (socket.readyState !== WebSocket.OPEN) {
+        return;
+      }
+      socket.send(Talkback.toPCM(e.inputBuffer.getChannelData(0)));
+    };
+    source.connect(this.processor);
+    this.processor.connect(this.context.destination);
+  }
+
+  public stop(): void {
+    this.processor?.disconnect();
+    this.stream?.getTracks().forEach((t) => t.stop());
+    this.context?.close().catch(console.error);
+    this.socket?.close();
+
+    this.processor = null;
+    this.stream = null;
+    this.context = null;
+    this.socket = null;
+  }
+
+  private static toPCM(samples: Float32Array): ArrayBuffer {
+    const buf = new ArrayBuffer(samples.length * 2);
+    const view = new DataView(buf);
+    samples.forEach((s, i) => {
+      const v = Math.max(-1, Math.min(1, s));
+      view.setInt16(i * 2, v < 0 ? v * 0x8000 : v * 0x7fff, true);
+    });
+    return buf;
+  }
+}
@@ -0,0 +1,6 @@
+// basePath is path prefix which the app is served under, e.g. behind reverse proxy.
+// Server tells it by meta tag in index.html.
+export const basePath = (
+  document.querySelector('meta[name="base-path"]')?.getAttribute('content') ??
+  ''
+).replace(/\/$/, '');
@@ -0,0 +1,99 @@
+package web
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sawadashota/unifi-doorbell-chime/web/api"
+	"github.com/sawadashota/unifi-doorbell-chime/web/frontend"
+	"github.com/sawadashota/unifi-doorbell-chime/x/tlsconfig"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// Server serves ringing page, dashboard and API on a single port
+type Server struct {
+	r      Registry
+	c      Configuration
+	logger logrus.FieldLogger
+}
+
+type Registry interface {
+	AppLogger(app string) logrus.FieldLogger
+	TLS() *tlsconfig.Manager
+	WebAPIServer() *api.Server
+	WebFrontendServer() *frontend.Server
+}
+
+type Configuration interface {
+	WebPort() int
+	WebBind() string
+	WebBasePath() string
+	ShutdownTimeoutSec() int
+}
+
+func New(r Registry, c Configuration) *Server {
+	return &Server{
+		r:      r,
+		c:      c,
+		logger: r.AppLogger("web"),
+	}
+}
+
+// Handler routes API under <base path>/api/v1 and everything else under base path to frontend
+func (s *Server) Handler() http.Handler {
+	basePath := s.c.WebBasePath()
+
+	m := mux.NewRouter()
+	base := m
+	if basePath != "" {
+		m.Path(basePath).Handler(http.RedirectHandler(basePath+"/", http.StatusMovedPermanently))
+		base = m.PathPrefix(basePath).Subrouter()
+	}
+	s.r.WebAPIServer().Mount(base, basePath)
+	base.PathPrefix("/").Handler(http.StripPrefix(basePath, s.r.WebFrontendServer()))
+
+	return s.r.TLS().HSTS(s.r.WebAPIServer().Handler(m))
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	addr := net.JoinHostPort(s.c.WebBind(), strconv.Itoa(s.c.WebPort()))
+	svr := &http.Server{
+		Addr:    addr,
+		Handler: s.Handler(),
+	}
+
+	// requests still in flight after shutdown are cancelled
+	abort := api.Graceful(svr)
+	defer abort()
+
+	tlsConfig, err := s.r.TLS().ServerConfig()
+	if err != nil {
+		return xerrors.Errorf("failed to configure TLS: %w", err)
+	}
+	svr.TLSConfig = tlsConfig
+
+	errCh := make(chan error, 1)
+	go func() {
+		s.logger.Infof("start web server. %s%s", s.r.TLS().LocalURL(s.c.WebPort()), s.c.WebBasePath())
+		if err := tlsconfig.ListenAndServe(svr); err != nil {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		s.logger.Info("Bye!")
+		// give in-flight requests time to finish and cancel the rest
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(s.c.ShutdownTimeoutSec())*time.Second)
+		defer cancel()
+		return svr.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		s.logger.Debugf("%+v", err)
+		return xerrors.Errorf("exit web server: %w", err)
+	}
+}
@@ -0,0 +1,116 @@
+package apiauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/xerrors"
+)
+
+// Scope is permission of API
+type Scope string
+
+const (
+	// ScopeRead allows to read doorbells, snapshots, live view, events and clips
+	ScopeRead Scope = "read"
+	// ScopeMessage allows to set message on doorbell screen and talk back
+	ScopeMessage Scope = "message"
+	// ScopeSettings allows to change settings of doorbells
+	ScopeSettings Scope = "settings"
+	// ScopeAdmin allows everything including debug endpoints
+	ScopeAdmin Scope = "admin"
+)
+
+// Scopes is set of scopes
+type Scopes []Scope
+
+// Allows reports whether scopes include required scope. Admin includes every scope.
+func (ss Scopes) Allows(required Scope) bool {
+	for _, s := range ss {
+		if s == required || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseScope returns scope of name or error if unknown
+func ParseScope(name string) (Scope, error) {
+	switch s := Scope(name); s {
+	case ScopeRead, ScopeMessage, ScopeSettings, ScopeAdmin:
+		return s, nil
+	}
+	return "", xerrors.Errorf("unknown scope %q. use read, message, settings or admin", name)
+}
+
+// ParseScopes parses names of scopes
+func ParseScopes(names []string) (Scopes, error) {
+	ss := make(Scopes, 0, len(names))
+	for _, name := range names {
+		s, err := ParseScope(name)
+		if err != nil {
+			return nil, err
+		}
+		ss = append(ss, s)
+	}
+	return ss, nil
+}
+
+// Token is API token defined in config. Only hash of token is stored.
+type Token struct {
+	Name   string   `mapstructure:"name"`
+	Hash   string   `mapstructure:"hash"`
+	Scopes []string `mapstructure:"scopes"`
+}
+
+const tokenHashPrefix = "sha256:"
+
+// GenerateToken returns new random token
+func GenerateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", xerrors.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns hash of token to be written in config
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return tokenHashPrefix + hex.EncodeToString(sum[:])
+}
+
+// Verify reports whether token is the one of t
+func (t Token) Verify(token string) bool {
+	want := strings.ToLower(t.Hash)
+	if !strings.HasPrefix(want, tokenHashPrefix) {
+		want = tokenHashPrefix + want
+	}
+	return subtle.ConstantTimeCompare([]byte(HashToken(token)), []byte(want)) == 1
+}
+
+// User is user defined in config who can log in to frontend
+type User struct {
+	Username     string   `mapstructure:"username"`
+	PasswordHash string   `mapstructure:"password_hash"`
+	Scopes       []string `mapstructure:"scopes"`
+}
+
+// HashPassword returns bcrypt hash of password to be written in config
+func HashPassword(password string) (string, error) {
+	b, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", xerrors.Errorf("failed to hash password: %w", err)
+	}
+	return string(b), nil
+}
+
+// Verify reports whether password is the one of u
+func (u User) Verify(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
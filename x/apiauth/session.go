@@ -0,0 +1,84 @@
+package apiauth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// Session is logged in state of a user
+type Session struct {
+	ID        string
+	Username  string
+	Scopes    Scopes
+	ExpiresAt time.Time
+}
+
+// SessionStore keeps sessions in memory. Sessions are lost on restart.
+type SessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]Session
+}
+
+func NewSessionStore() *SessionStore {
+	return &SessionStore{
+		sessions: make(map[string]Session),
+	}
+}
+
+// Create starts session of user valid for ttl
+func (s *SessionStore) Create(username string, scopes Scopes, ttl time.Duration) (Session, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return Session{}, xerrors.Errorf("failed to generate session ID: %w", err)
+	}
+
+	sess := Session{
+		ID:        base64.RawURLEncoding.EncodeToString(b),
+		Username:  username,
+		Scopes:    scopes,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep()
+	s.sessions[sess.ID] = sess
+	return sess, nil
+}
+
+// Get returns session of id if it is not expired
+func (s *SessionStore) Get(id string) (Session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[id]
+	if !ok {
+		return Session{}, false
+	}
+	if time.Now().After(sess.ExpiresAt) {
+		delete(s.sessions, id)
+		return Session{}, false
+	}
+	return sess, true
+}
+
+// Delete ends session of id
+func (s *SessionStore) Delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+}
+
+// sweep removes expired sessions. caller must hold lock
+func (s *SessionStore) sweep() {
+	now := time.Now()
+	for id, sess := range s.sessions {
+		if now.After(sess.ExpiresAt) {
+			delete(s.sessions, id)
+		}
+	}
+}
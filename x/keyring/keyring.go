@@ -0,0 +1,78 @@
+// Package keyring stores secrets in OS keyring via its command line tool.
+// macOS uses `security` (Keychain) and Linux uses `secret-tool` (Secret Service).
+package keyring
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	ErrNotFound    = xerrors.New("secret is not found in keyring")
+	ErrUnsupported = xerrors.New("keyring is not supported on this platform")
+)
+
+// Get returns secret stored for the service and the key
+func Get(service, key string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", service, "-a", key, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", service, "key", key)
+	default:
+		return "", ErrUnsupported
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", xerrors.Errorf("%s/%s: %w", service, key, ErrNotFound)
+		}
+		return "", xerrors.Errorf("failed to read keyring: %w", err)
+	}
+
+	v := strings.TrimRight(stdout.String(), "\r\n")
+	if v == "" {
+		return "", xerrors.Errorf("%s/%s: %w", service, key, ErrNotFound)
+	}
+	return v, nil
+}
+
+// Set stores secret for the service and the key. Existing secret is overwritten.
+func Set(service, key, secret string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		// command is given on stdin of interactive mode not to show the secret in process list.
+		// the secret is hex encoded not to be parsed as part of the command.
+		cmd = exec.Command("security", "-i")
+		cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n",
+			strconv.Quote(service), strconv.Quote(key), hex.EncodeToString([]byte(secret))))
+	case "linux":
+		cmd = exec.Command("secret-tool", "store", "--label", service+" "+key, "service", service, "key", key)
+		cmd.Stdin = strings.NewReader(secret)
+	default:
+		return ErrUnsupported
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return xerrors.Errorf("failed to write keyring: %s: %w", strings.TrimSpace(stderr.String()), err)
+	}
+	// interactive mode of security exits successfully even if the command fails
+	if msg := strings.TrimSpace(stderr.String()); runtime.GOOS == "darwin" && msg != "" {
+		return xerrors.Errorf("failed to write keyring: %s", msg)
+	}
+	return nil
+}
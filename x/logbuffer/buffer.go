@@ -0,0 +1,87 @@
+package logbuffer
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const subscriberBufferSize = 64
+
+// Buffer is logrus hook keeping recent log lines and broadcasting new lines to subscribers
+type Buffer struct {
+	mu        sync.RWMutex
+	size      int
+	lines     []string
+	subs      map[chan string]struct{}
+	formatter logrus.Formatter
+}
+
+var _ logrus.Hook = new(Buffer)
+
+// New returns Buffer keeping at most size lines
+func New(size int) *Buffer {
+	return &Buffer{
+		size: size,
+		subs: make(map[chan string]struct{}),
+		formatter: &logrus.TextFormatter{
+			DisableColors: true,
+			FullTimestamp: true,
+		},
+	}
+}
+
+func (b *Buffer) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (b *Buffer) Fire(entry *logrus.Entry) error {
+	line, err := b.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lines = append(b.lines, string(line))
+	if len(b.lines) > b.size {
+		b.lines = b.lines[len(b.lines)-b.size:]
+	}
+	for ch := range b.subs {
+		// never block logging by slow subscriber
+		select {
+		case ch <- string(line):
+		default:
+		}
+	}
+	return nil
+}
+
+// Lines returns recent log lines
+func (b *Buffer) Lines() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]string(nil), b.lines...)
+}
+
+// Subscribe returns recent log lines, channel receiving lines logged after them and function to stop subscription.
+// Lines are taken with subscription at once so that no line is missed or received twice.
+func (b *Buffer) Subscribe() ([]string, <-chan string, func()) {
+	ch := make(chan string, subscriberBufferSize)
+
+	b.mu.Lock()
+	lines := append([]string(nil), b.lines...)
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return lines, ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
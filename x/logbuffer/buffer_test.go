@@ -0,0 +1,39 @@
+package logbuffer
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestBufferSubscribe(t *testing.T) {
+	b := New(10)
+	l := logrus.New()
+	l.SetOutput(ioutil.Discard)
+	l.AddHook(b)
+
+	l.Info("first")
+	l.Info("second")
+	recent, lines, stop := b.Subscribe()
+	defer stop()
+	l.Info("third")
+
+	if len(recent) != 2 || !strings.Contains(recent[0], "first") || !strings.Contains(recent[1], "second") {
+		t.Fatalf("recent lines: got %q", recent)
+	}
+	select {
+	case line := <-lines:
+		if !strings.Contains(line, "third") {
+			t.Errorf("got %q, want third line", line)
+		}
+	default:
+		t.Fatal("line logged after subscription is not received")
+	}
+	select {
+	case line := <-lines:
+		t.Errorf("unexpected line %q", line)
+	default:
+	}
+}
@@ -0,0 +1,113 @@
+package netenv
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os/exec"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+var errNotFound = xerrors.New("not found")
+
+// State is network environment which the host is connected to
+type State struct {
+	SSID          string
+	GatewayIP     net.IP
+	GatewayMAC    net.HardwareAddr
+	Addrs         []*net.IPNet
+	InterfaceMACs []net.HardwareAddr
+	NVRReachable  bool
+}
+
+const nvrDialTimeout = 3 * time.Second
+
+// Detect inspects current network environment.
+// Values which can't be detected are left empty. nvrAddr is host:port of UniFi Protect.
+func Detect(ctx context.Context, nvrAddr string) State {
+	var s State
+	s.SSID, _ = ssid(ctx)
+	s.GatewayIP, s.GatewayMAC, _ = gateway(ctx)
+	s.Addrs, s.InterfaceMACs = interfaces()
+
+	if nvrAddr != "" {
+		d := net.Dialer{Timeout: nvrDialTimeout}
+		if conn, err := d.DialContext(ctx, "tcp", nvrAddr); err == nil {
+			_ = conn.Close()
+			s.NVRReachable = true
+		}
+	}
+	return s
+}
+
+// interfaces returns IPv4 addresses and MAC addresses of up non-loopback interfaces
+func interfaces() ([]*net.IPNet, []net.HardwareAddr) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, nil
+	}
+
+	var (
+		addrs []*net.IPNet
+		macs  []net.HardwareAddr
+	)
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		ias, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		hasIPv4 := false
+		for _, a := range ias {
+			if n, ok := a.(*net.IPNet); ok && n.IP.To4() != nil {
+				addrs = append(addrs, n)
+				hasIPv4 = true
+			}
+		}
+		if hasIPv4 && len(iface.HardwareAddr) > 0 {
+			macs = append(macs, iface.HardwareAddr)
+		}
+	}
+	return addrs, macs
+}
+
+// arpLookup finds MAC address of ip from output of arp command
+func arpLookup(ctx context.Context, ip net.IP) (net.HardwareAddr, error) {
+	out, err := exec.CommandContext(ctx, "arp", "-n", ip.String()).Output()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range strings.Fields(string(out)) {
+		if mac, err := parseMAC(f); err == nil {
+			return mac, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+// parseMAC parses MAC address also in short form like 0:0:5e:0:53:1 printed by BSD arp
+func parseMAC(s string) (net.HardwareAddr, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) == 6 {
+		for i, p := range parts {
+			if len(p) == 1 {
+				parts[i] = "0" + p
+			}
+		}
+		s = strings.Join(parts, ":")
+	}
+	return net.ParseMAC(s)
+}
+
+func command(ctx context.Context, name string, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSpace(out)), nil
+}
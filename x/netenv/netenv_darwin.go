@@ -0,0 +1,57 @@
+package netenv
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+const airport = "/System/Library/PrivateFrameworks/Apple80211.framework/Versions/Current/Resources/airport"
+
+func ssid(ctx context.Context) (string, error) {
+	if out, err := command(ctx, airport, "-I"); err == nil {
+		for _, line := range strings.Split(out, "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, "SSID: ") {
+				return strings.TrimPrefix(line, "SSID: "), nil
+			}
+		}
+	}
+
+	// airport command is removed on recent macOS
+	out, err := command(ctx, "networksetup", "-getairportnetwork", "en0")
+	if err != nil {
+		return "", err
+	}
+	const prefix = "Current Wi-Fi Network: "
+	if i := strings.Index(out, prefix); i >= 0 {
+		return strings.TrimSpace(out[i+len(prefix):]), nil
+	}
+	return "", errNotFound
+}
+
+func gateway(ctx context.Context) (net.IP, net.HardwareAddr, error) {
+	out, err := command(ctx, "route", "-n", "get", "default")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ip net.IP
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "gateway: ") {
+			ip = net.ParseIP(strings.TrimPrefix(line, "gateway: "))
+		}
+	}
+	if ip == nil {
+		return nil, nil, errNotFound
+	}
+
+	mac, err := arpLookup(ctx, ip)
+	return ip, mac, err
+}
+
+// Changes returns nil because there is no change notification on darwin. Network has to be polled.
+func Changes(_ context.Context) <-chan struct{} {
+	return nil
+}
@@ -0,0 +1,135 @@
+package netenv
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+)
+
+func ssid(ctx context.Context) (string, error) {
+	if s, err := command(ctx, "iwgetid", "-r"); err == nil && s != "" {
+		return s, nil
+	}
+
+	out, err := command(ctx, "nmcli", "-t", "-f", "active,ssid", "dev", "wifi")
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "yes:") {
+			return strings.TrimPrefix(line, "yes:"), nil
+		}
+	}
+	return "", errNotFound
+}
+
+// gateway finds default gateway from /proc/net/route and its MAC address from /proc/net/arp
+func gateway(ctx context.Context) (net.IP, net.HardwareAddr, error) {
+	ip, err := defaultGateway()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := os.Open("/proc/net/arp")
+	if err != nil {
+		mac, err := arpLookup(ctx, ip)
+		return ip, mac, err
+	}
+	defer f.Close()
+
+	// IP address  HW type  Flags  HW address  Mask  Device
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 4 || fields[0] != ip.String() {
+			continue
+		}
+		mac, err := net.ParseMAC(fields[3])
+		return ip, mac, err
+	}
+	return ip, nil, errNotFound
+}
+
+func defaultGateway() (net.IP, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// Iface  Destination  Gateway  Flags ...
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 3 || fields[1] != "00000000" || fields[2] == "00000000" {
+			continue
+		}
+		b, err := hex.DecodeString(fields[2])
+		if err != nil || len(b) != 4 {
+			continue
+		}
+		// little endian
+		ip := make(net.IP, 4)
+		binary.BigEndian.PutUint32(ip, binary.LittleEndian.Uint32(b))
+		return ip, nil
+	}
+	return nil, errNotFound
+}
+
+// multicast groups of rtnetlink
+const (
+	rtmgrpLink       = 0x1
+	rtmgrpIPv4IfAddr = 0x10
+	rtmgrpIPv4Route  = 0x40
+)
+
+// Changes notifies changes of links, addresses and routes via netlink until ctx is done.
+// It returns nil if netlink is not available.
+func Changes(ctx context.Context) <-chan struct{} {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC, syscall.NETLINK_ROUTE)
+	if err != nil {
+		return nil
+	}
+	sa := &syscall.SockaddrNetlink{
+		Family: syscall.AF_NETLINK,
+		Groups: rtmgrpLink | rtmgrpIPv4IfAddr | rtmgrpIPv4Route,
+	}
+	if err := syscall.Bind(fd, sa); err != nil {
+		_ = syscall.Close(fd)
+		return nil
+	}
+
+	// wake up periodically to notice ctx is done
+	tv := syscall.Timeval{Sec: 1}
+	if err := syscall.SetsockoptTimeval(fd, syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, &tv); err != nil {
+		_ = syscall.Close(fd)
+		return nil
+	}
+
+	ch := make(chan struct{}, 1)
+	go func() {
+		defer syscall.Close(fd)
+		defer close(ch)
+
+		buf := make([]byte, os.Getpagesize())
+		for ctx.Err() == nil {
+			n, _, err := syscall.Recvfrom(fd, buf, 0)
+			if err == syscall.EAGAIN || err == syscall.EINTR {
+				continue
+			}
+			if err != nil || n == 0 {
+				return
+			}
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return ch
+}
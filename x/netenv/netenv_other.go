@@ -0,0 +1,26 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package netenv
+
+import (
+	"context"
+	"net"
+
+	"golang.org/x/xerrors"
+)
+
+var errUnsupported = xerrors.New("unsupported platform")
+
+func ssid(_ context.Context) (string, error) {
+	return "", errUnsupported
+}
+
+func gateway(ctx context.Context) (net.IP, net.HardwareAddr, error) {
+	return nil, nil, errUnsupported
+}
+
+// Changes returns nil because there is no change notification on this platform. Network has to be polled.
+func Changes(_ context.Context) <-chan struct{} {
+	return nil
+}
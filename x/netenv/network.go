@@ -0,0 +1,119 @@
+package netenv
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Network is conditions of acceptable network. Every given condition has to be satisfied.
+type Network struct {
+	Name         string `mapstructure:"name"`
+	SSID         string `mapstructure:"ssid"`
+	GatewayMAC   string `mapstructure:"gateway_mac"`
+	CIDR         string `mapstructure:"cidr"`
+	NVRReachable bool   `mapstructure:"nvr_reachable"`
+	// InterfaceMAC is MAC address of the host's own interface
+	InterfaceMAC string `mapstructure:"interface_mac"`
+}
+
+// IsEmpty reports whether no condition is given
+func (n Network) IsEmpty() bool {
+	return n.SSID == "" && n.GatewayMAC == "" && n.CIDR == "" && !n.NVRReachable && n.InterfaceMAC == ""
+}
+
+// Match reports whether s satisfies every condition of n
+func (n Network) Match(s State) bool {
+	if n.IsEmpty() {
+		return false
+	}
+	if n.SSID != "" && n.SSID != s.SSID {
+		return false
+	}
+	if n.GatewayMAC != "" && !equalMAC(n.GatewayMAC, s.GatewayMAC) {
+		return false
+	}
+	if n.CIDR != "" && !containsAny(n.CIDR, s.Addrs) {
+		return false
+	}
+	if n.NVRReachable && !s.NVRReachable {
+		return false
+	}
+	if n.InterfaceMAC != "" {
+		matched := false
+		for _, mac := range s.InterfaceMACs {
+			if equalMAC(n.InterfaceMAC, mac) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func (n Network) String() string {
+	if n.Name != "" {
+		return n.Name
+	}
+
+	var conds []string
+	if n.SSID != "" {
+		conds = append(conds, "ssid="+n.SSID)
+	}
+	if n.GatewayMAC != "" {
+		conds = append(conds, "gateway_mac="+n.GatewayMAC)
+	}
+	if n.CIDR != "" {
+		conds = append(conds, "cidr="+n.CIDR)
+	}
+	if n.NVRReachable {
+		conds = append(conds, "nvr_reachable")
+	}
+	if n.InterfaceMAC != "" {
+		conds = append(conds, "interface_mac="+n.InterfaceMAC)
+	}
+	return strings.Join(conds, ",")
+}
+
+// MatchAny returns first network which s satisfies
+func MatchAny(networks []Network, s State) (Network, bool) {
+	for _, n := range networks {
+		if n.Match(s) {
+			return n, true
+		}
+	}
+	return Network{}, false
+}
+
+func (s State) String() string {
+	addrs := make([]string, 0, len(s.Addrs))
+	for _, a := range s.Addrs {
+		addrs = append(addrs, a.String())
+	}
+	return fmt.Sprintf("ssid=%q gateway=%s (%s) addrs=%s nvr_reachable=%t",
+		s.SSID, s.GatewayIP, s.GatewayMAC, strings.Join(addrs, ","), s.NVRReachable)
+}
+
+func equalMAC(want string, got net.HardwareAddr) bool {
+	mac, err := parseMAC(want)
+	if err != nil || got == nil {
+		return false
+	}
+	return mac.String() == got.String()
+}
+
+func containsAny(cidr string, addrs []*net.IPNet) bool {
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	for _, a := range addrs {
+		if n.Contains(a.IP) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package sdnotify
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// Ready tells systemd that startup is finished
+	Ready = "READY=1"
+	// Stopping tells systemd that shutdown is started
+	Stopping = "STOPPING=1"
+	// Watchdog keeps alive watchdog of systemd
+	Watchdog = "WATCHDOG=1"
+)
+
+// Notify sends state to systemd via $NOTIFY_SOCKET.
+// It returns false without error if the process is not run by systemd with notify type.
+func Notify(state string) (bool, error) {
+	path := os.Getenv("NOTIFY_SOCKET")
+	if path == "" {
+		return false, nil
+	}
+	// abstract namespace socket
+	if path[0] == '@' {
+		path = "\x00" + path[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return false, xerrors.Errorf("failed to connect to %s: %w", os.Getenv("NOTIFY_SOCKET"), err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return false, xerrors.Errorf("failed to notify %s: %w", state, err)
+	}
+	return true, nil
+}
+
+// WatchdogInterval returns interval which watchdog has to be kept alive within.
+// It returns false if watchdog is not enabled for the process.
+func WatchdogInterval() (time.Duration, bool) {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0, false
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0, false
+	}
+	return time.Duration(usec) * time.Microsecond, true
+}
@@ -0,0 +1,63 @@
+package stream
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"time"
+)
+
+// FakeSource generates moving test pattern without camera.
+// Use it for development or to check the streaming pipeline end to end.
+type FakeSource struct {
+	fps           int
+	width, height int
+}
+
+func NewFakeSource(fps int) *FakeSource {
+	return &FakeSource{
+		fps:    fps,
+		width:  640,
+		height: 360,
+	}
+}
+
+func (s *FakeSource) Open(ctx context.Context, _ string) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+
+	go func() {
+		ticker := time.NewTicker(time.Second / time.Duration(s.fps))
+		defer ticker.Stop()
+
+		for i := 0; ; i++ {
+			if err := jpeg.Encode(pw, s.frame(i), nil); err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				_ = pw.CloseWithError(ctx.Err())
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return pr, nil
+}
+
+func (s *FakeSource) frame(i int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, s.width, s.height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.Gray{Y: 0x38}}, image.Point{}, draw.Src)
+
+	barWidth := s.width / 10
+	x := (i * 8) % (s.width - barWidth)
+	bar := image.Rect(x, 0, x+barWidth, s.height)
+	draw.Draw(img, bar, &image.Uniform{C: color.RGBA{R: 0x00, G: 0x70, B: 0xf3, A: 0xff}}, image.Point{}, draw.Src)
+
+	return img
+}
@@ -0,0 +1,81 @@
+package stream
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+type URLResolver interface {
+	GetRTSPURL(ctx context.Context, doorbellID string) (string, error)
+}
+
+// FFmpegSource transcodes RTSP stream of the camera to MJPEG by ffmpeg command
+type FFmpegSource struct {
+	path     string
+	fps      int
+	resolver URLResolver
+	logger   logrus.FieldLogger
+}
+
+func NewFFmpegSource(path string, fps int, resolver URLResolver, logger logrus.FieldLogger) *FFmpegSource {
+	return &FFmpegSource{
+		path:     path,
+		fps:      fps,
+		resolver: resolver,
+		logger:   logger,
+	}
+}
+
+func (s *FFmpegSource) Open(ctx context.Context, doorbellID string) (io.ReadCloser, error) {
+	u, err := s.resolver.GetRTSPURL(ctx, doorbellID)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to resolve RTSP URL: %w", err)
+	}
+
+	// #nosec G204
+	cmd := exec.CommandContext(ctx, s.path,
+		"-loglevel", "error",
+		"-rtsp_transport", "tcp",
+		"-i", u,
+		"-an",
+		"-f", "mjpeg",
+		"-q:v", "5",
+		"-r", strconv.Itoa(s.fps),
+		"pipe:1",
+	)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to pipe stdout of ffmpeg: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, xerrors.Errorf("failed to start ffmpeg: %w", err)
+	}
+	s.logger.Debugf("start ffmpeg for %s", doorbellID)
+
+	return &ffmpegReader{
+		ReadCloser: stdout,
+		cmd:        cmd,
+		logger:     s.logger,
+	}, nil
+}
+
+type ffmpegReader struct {
+	io.ReadCloser
+	cmd    *exec.Cmd
+	logger logrus.FieldLogger
+}
+
+func (r *ffmpegReader) Close() error {
+	if r.cmd.Process != nil {
+		_ = r.cmd.Process.Kill()
+	}
+	if err := r.cmd.Wait(); err != nil {
+		r.logger.Debugf("ffmpeg exited: %s", err)
+	}
+	return nil
+}
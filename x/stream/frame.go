@@ -0,0 +1,71 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	jpegSOI = []byte{0xff, 0xd8}
+	jpegEOI = []byte{0xff, 0xd9}
+)
+
+const maxFrameSize = 8 << 20
+
+// FrameScanner splits concatenated JPEG images (e.g. output of `ffmpeg -f mjpeg`) into frames
+type FrameScanner struct {
+	r *bufio.Reader
+}
+
+func NewFrameScanner(r io.Reader) *FrameScanner {
+	return &FrameScanner{
+		r: bufio.NewReaderSize(r, 64<<10),
+	}
+}
+
+// Next returns next JPEG frame including SOI and EOI markers
+func (s *FrameScanner) Next() ([]byte, error) {
+	if err := s.skipUntilSOI(); err != nil {
+		return nil, err
+	}
+
+	frame := bytes.NewBuffer(make([]byte, 0, 64<<10))
+	frame.Write(jpegSOI)
+
+	var prev byte
+	for {
+		b, err := s.r.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		frame.WriteByte(b)
+
+		if prev == jpegEOI[0] && b == jpegEOI[1] {
+			return frame.Bytes(), nil
+		}
+		if frame.Len() > maxFrameSize {
+			return nil, xerrors.Errorf("frame exceeds %d bytes", maxFrameSize)
+		}
+		prev = b
+	}
+}
+
+func (s *FrameScanner) skipUntilSOI() error {
+	var prev byte
+	for {
+		b, err := s.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if prev == jpegSOI[0] && b == jpegSOI[1] {
+			return nil
+		}
+		prev = b
+	}
+}
@@ -0,0 +1,45 @@
+package stream
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strconv"
+
+	"golang.org/x/xerrors"
+)
+
+// MJPEGWriter writes JPEG frames as multipart/x-mixed-replace response which browsers render as video in <img>
+type MJPEGWriter struct {
+	mw *multipart.Writer
+}
+
+func NewMJPEGWriter(w io.Writer) *MJPEGWriter {
+	return &MJPEGWriter{
+		mw: multipart.NewWriter(w),
+	}
+}
+
+func (w *MJPEGWriter) ContentType() string {
+	return fmt.Sprintf("multipart/x-mixed-replace; boundary=%s", w.mw.Boundary())
+}
+
+func (w *MJPEGWriter) WriteFrame(frame []byte) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Type", "image/jpeg")
+	header.Set("Content-Length", strconv.Itoa(len(frame)))
+
+	part, err := w.mw.CreatePart(header)
+	if err != nil {
+		return xerrors.Errorf("failed to create part: %w", err)
+	}
+	if _, err := part.Write(frame); err != nil {
+		return xerrors.Errorf("failed to write frame: %w", err)
+	}
+	return nil
+}
+
+func (w *MJPEGWriter) Close() error {
+	return w.mw.Close()
+}
@@ -0,0 +1,88 @@
+package stream
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+type Proxy struct {
+	r      Registry
+	c      Configuration
+	logger logrus.FieldLogger
+}
+
+type Registry interface {
+	AppLogger(app string) logrus.FieldLogger
+	UnifiClient() *unifi.Client
+}
+
+type Configuration interface {
+	StreamSource() string
+	StreamFFmpegPath() string
+	StreamFPS() int
+}
+
+func NewProxy(r Registry, c Configuration) *Proxy {
+	return &Proxy{
+		r:      r,
+		c:      c,
+		logger: r.AppLogger("stream"),
+	}
+}
+
+func (p *Proxy) source() Source {
+	switch p.c.StreamSource() {
+	case SourceFake:
+		return NewFakeSource(p.c.StreamFPS())
+	default:
+		return NewFFmpegSource(p.c.StreamFFmpegPath(), p.c.StreamFPS(), p.r.UnifiClient(), p.logger)
+	}
+}
+
+// ServeMJPEG streams live view of the doorbell until ctx is done or client disconnects
+func (p *Proxy) ServeMJPEG(ctx context.Context, w http.ResponseWriter, doorbellID string) error {
+	return Pipe(ctx, p.source(), w, doorbellID)
+}
+
+// Pipe reads frames from src and writes them to w as MJPEG
+func Pipe(ctx context.Context, src Source, w http.ResponseWriter, doorbellID string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rc, err := src.Open(ctx, doorbellID)
+	if err != nil {
+		return xerrors.Errorf("failed to open stream: %w", err)
+	}
+	defer rc.Close()
+
+	flusher, _ := w.(http.Flusher)
+	mw := NewMJPEGWriter(w)
+	scanner := NewFrameScanner(rc)
+	for written := false; ; written = true {
+		frame, err := scanner.Next()
+		if err != nil {
+			if ctx.Err() != nil || written {
+				// client went away or stream ended
+				return nil
+			}
+			// nothing is sent to client yet so that caller can still respond error status
+			return xerrors.Errorf("no frame received: %w", err)
+		}
+
+		if !written {
+			w.Header().Set("Content-Type", mw.ContentType())
+			w.Header().Set("Cache-Control", "no-cache")
+		}
+		if err := mw.WriteFrame(frame); err != nil {
+			// client went away
+			return nil
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
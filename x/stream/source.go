@@ -0,0 +1,16 @@
+package stream
+
+import (
+	"context"
+	"io"
+)
+
+// Source opens stream of concatenated JPEG frames for a camera
+type Source interface {
+	Open(ctx context.Context, doorbellID string) (io.ReadCloser, error)
+}
+
+const (
+	SourceFFmpeg = "ffmpeg"
+	SourceFake   = "fake"
+)
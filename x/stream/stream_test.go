@@ -0,0 +1,116 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"image/jpeg"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestFrameScanner(t *testing.T) {
+	first := []byte{0xff, 0xd8, 0x01, 0x02, 0xff, 0xd9}
+	second := []byte{0xff, 0xd8, 0xff, 0x00, 0x03, 0xff, 0xd9}
+
+	var in bytes.Buffer
+	in.WriteString("garbage before first frame")
+	in.Write(first)
+	in.WriteString("garbage between frames")
+	in.Write(second)
+	in.Write([]byte{0xff, 0xd8, 0x04})
+
+	s := NewFrameScanner(&in)
+	for i, want := range [][]byte{first, second} {
+		got, err := s.Next()
+		if err != nil {
+			t.Fatalf("frame %d: unexpected error: %s", i, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("frame %d: got %x, want %x", i, got, want)
+		}
+	}
+
+	if _, err := s.Next(); err != io.ErrUnexpectedEOF {
+		t.Errorf("truncated frame: got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestPipeFakeSource(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	w := httptest.NewRecorder()
+	if err := Pipe(ctx, NewFakeSource(20), w, "doorbell"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("invalid Content-Type: %s", err)
+	}
+	if mediaType != "multipart/x-mixed-replace" {
+		t.Fatalf("Content-Type: got %s, want multipart/x-mixed-replace", mediaType)
+	}
+	if params["boundary"] == "" {
+		t.Fatal("boundary is empty")
+	}
+
+	// stream is cut by cancel, so the last part may be incomplete
+	r := multipart.NewReader(w.Body, params["boundary"])
+	frames := 0
+	for {
+		part, err := r.NextPart()
+		if err != nil {
+			break
+		}
+		frame, err := ioutil.ReadAll(part)
+		if err != nil {
+			break
+		}
+
+		if ct := part.Header.Get("Content-Type"); ct != "image/jpeg" {
+			t.Errorf("frame %d: Content-Type is %s", frames, ct)
+		}
+		if cl := part.Header.Get("Content-Length"); cl != strconv.Itoa(len(frame)) {
+			t.Errorf("frame %d: Content-Length is %s but frame is %d bytes", frames, cl, len(frame))
+		}
+		img, err := jpeg.Decode(bytes.NewReader(frame))
+		if err != nil {
+			t.Fatalf("frame %d: invalid JPEG: %s", frames, err)
+		}
+		if b := img.Bounds(); b.Dx() != 640 || b.Dy() != 360 {
+			t.Errorf("frame %d: size is %dx%d", frames, b.Dx(), b.Dy())
+		}
+		frames++
+	}
+
+	if frames < 2 {
+		t.Errorf("got %d frames, want at least 2", frames)
+	}
+}
+
+type emptySource struct{}
+
+func (emptySource) Open(context.Context, string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(nil)), nil
+}
+
+func TestPipeNoFrame(t *testing.T) {
+	w := httptest.NewRecorder()
+	if err := Pipe(context.Background(), emptySource{}, w, "doorbell"); err == nil {
+		t.Fatal("expected error for stream without frame")
+	}
+	// caller can still respond error
+	if ct := w.Header().Get("Content-Type"); ct != "" {
+		t.Errorf("Content-Type is set to %s", ct)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("%d bytes are written", w.Body.Len())
+	}
+}
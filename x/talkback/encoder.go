@@ -0,0 +1,128 @@
+package talkback
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+const (
+	EncoderFFmpeg = "ffmpeg"
+	EncoderPCM    = "pcm"
+)
+
+// Encoder accepts mono signed 16-bit little endian PCM and writes encoded audio to underlying writer
+type Encoder interface {
+	io.WriteCloser
+}
+
+type EncoderFactory interface {
+	NewEncoder(ctx context.Context, in, out Format, codec string, w io.Writer) (Encoder, error)
+}
+
+// PCMEncoderFactory creates encoder which only resamples and duplicates channels.
+// It does not depend on any external command so that it is handy to check talkback path.
+type PCMEncoderFactory struct{}
+
+func (PCMEncoderFactory) NewEncoder(_ context.Context, in, out Format, _ string, w io.Writer) (Encoder, error) {
+	return &pcmEncoder{
+		out:       out,
+		w:         w,
+		resampler: newResampler(in.SamplingRate, out.SamplingRate),
+	}, nil
+}
+
+type pcmEncoder struct {
+	out       Format
+	w         io.Writer
+	resampler *resampler
+	// odd is trailing byte of the last chunk which is the first half of a sample
+	odd []byte
+}
+
+func (e *pcmEncoder) Write(p []byte) (int, error) {
+	b := p
+	if len(e.odd) > 0 {
+		b = append(e.odd, p...)
+	}
+	n := len(b) &^ 1
+	e.odd = append([]byte(nil), b[n:]...)
+
+	if err := e.write(e.resampler.resample(decodeS16LE(b[:n]))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (e *pcmEncoder) write(samples []int16) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	_, err := e.w.Write(encodeS16LE(samples, e.out.Channels))
+	return err
+}
+
+// Close writes the rest of resampled audio
+func (e *pcmEncoder) Close() error {
+	return e.write(e.resampler.flush())
+}
+
+// FFmpegEncoderFactory creates encoder transcoding PCM by ffmpeg command
+type FFmpegEncoderFactory struct {
+	Path   string
+	Logger logrus.FieldLogger
+}
+
+func (f FFmpegEncoderFactory) NewEncoder(ctx context.Context, in, out Format, codec string, w io.Writer) (Encoder, error) {
+	muxer := codec
+	if codec == "aac" {
+		muxer = "adts"
+	}
+
+	// #nosec G204
+	cmd := exec.CommandContext(ctx, f.Path,
+		"-loglevel", "error",
+		"-f", "s16le",
+		"-ar", strconv.Itoa(in.SamplingRate),
+		"-ac", "1",
+		"-i", "pipe:0",
+		"-acodec", codec,
+		"-ar", strconv.Itoa(out.SamplingRate),
+		"-ac", strconv.Itoa(out.Channels),
+		"-f", muxer,
+		"pipe:1",
+	)
+	cmd.Stdout = w
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to pipe stdin of ffmpeg: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, xerrors.Errorf("failed to start ffmpeg: %w", err)
+	}
+
+	return &ffmpegEncoder{
+		WriteCloser: stdin,
+		cmd:         cmd,
+		logger:      f.Logger,
+	}, nil
+}
+
+type ffmpegEncoder struct {
+	io.WriteCloser
+	cmd    *exec.Cmd
+	logger logrus.FieldLogger
+}
+
+func (e *ffmpegEncoder) Close() error {
+	// closing stdin makes ffmpeg flush remaining audio and exit
+	_ = e.WriteCloser.Close()
+	if err := e.cmd.Wait(); err != nil {
+		e.logger.Debugf("ffmpeg exited: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package talkback
+
+import (
+	"encoding/binary"
+)
+
+// Format describes raw PCM audio
+type Format struct {
+	SamplingRate int
+	Channels     int
+}
+
+// resampler converts mono signed 16-bit samples from one sampling rate to another by linear interpolation.
+// Samples are given in chunks, so it keeps position of output and samples needed by the next chunk
+// not to drift at boundaries of chunks.
+type resampler struct {
+	from, to int64
+	// buf is samples from index base of input which are not consumed yet
+	buf  []int16
+	base int64
+	// produced is number of output samples
+	produced int64
+}
+
+func newResampler(from, to int) *resampler {
+	return &resampler{from: int64(from), to: int64(to)}
+}
+
+// position returns index of input sample and fraction to the next one of the next output sample
+func (r *resampler) position() (int64, float64) {
+	num := r.produced * r.from
+	return num/r.to - r.base, float64(num%r.to) / float64(r.to)
+}
+
+// resample returns output samples which can be interpolated by samples given so far
+func (r *resampler) resample(samples []int16) []int16 {
+	if r.from == r.to {
+		return samples
+	}
+
+	r.buf = append(r.buf, samples...)
+	var out []int16
+	for {
+		idx, frac := r.position()
+		if idx+1 >= int64(len(r.buf)) {
+			break
+		}
+		out = append(out, int16(float64(r.buf[idx])*(1-frac)+float64(r.buf[idx+1])*frac))
+		r.produced++
+	}
+
+	// drop samples before the next output
+	idx, _ := r.position()
+	if idx > int64(len(r.buf)) {
+		idx = int64(len(r.buf))
+	}
+	r.buf = append(r.buf[:0], r.buf[idx:]...)
+	r.base += idx
+	return out
+}
+
+// flush returns output samples after the last input sample which has no next one to interpolate
+func (r *resampler) flush() []int16 {
+	if r.from == r.to {
+		return nil
+	}
+
+	var out []int16
+	for {
+		idx, _ := r.position()
+		if idx >= int64(len(r.buf)) {
+			break
+		}
+		out = append(out, r.buf[len(r.buf)-1])
+		r.produced++
+	}
+	r.buf = r.buf[:0]
+	return out
+}
+
+func decodeS16LE(b []byte) []int16 {
+	samples := make([]int16, len(b)/2)
+	for i := range samples {
+		samples[i] = int16(binary.LittleEndian.Uint16(b[i*2:]))
+	}
+	return samples
+}
+
+func encodeS16LE(samples []int16, channels int) []byte {
+	if channels < 1 {
+		channels = 1
+	}
+	b := make([]byte, len(samples)*2*channels)
+	for i, s := range samples {
+		for ch := 0; ch < channels; ch++ {
+			binary.LittleEndian.PutUint16(b[(i*channels+ch)*2:], uint16(s))
+		}
+	}
+	return b
+}
@@ -0,0 +1,64 @@
+package talkback
+
+import (
+	"io"
+	"net"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+// Receiver is a fake talkback endpoint which writes every received datagram to w.
+// Point talkback.address to it to check talkback without a doorbell.
+type Receiver struct {
+	conn net.PacketConn
+	w    io.Writer
+
+	mu      sync.Mutex
+	packets int
+	bytes   int
+}
+
+func ListenReceiver(addr string, w io.Writer) (*Receiver, error) {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to listen %s: %w", addr, err)
+	}
+	r := &Receiver{
+		conn: conn,
+		w:    w,
+	}
+	go r.serve()
+	return r, nil
+}
+
+func (r *Receiver) Addr() net.Addr {
+	return r.conn.LocalAddr()
+}
+
+func (r *Receiver) serve() {
+	buf := make([]byte, 64<<10)
+	for {
+		n, _, err := r.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		r.mu.Lock()
+		r.packets++
+		r.bytes += n
+		_, _ = r.w.Write(buf[:n])
+		r.mu.Unlock()
+	}
+}
+
+// Stats returns number of received packets and bytes
+func (r *Receiver) Stats() (packets int, bytes int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.packets, r.bytes
+}
+
+func (r *Receiver) Close() error {
+	return r.conn.Close()
+}
@@ -0,0 +1,78 @@
+package talkback
+
+import (
+	"bytes"
+	"net"
+
+	"golang.org/x/xerrors"
+)
+
+const maxDatagramSize = 1024
+
+// UDPSender sends encoded audio to the talkback endpoint of the doorbell.
+// AAC is sent one ADTS frame per datagram and the others are split by maxDatagramSize.
+type UDPSender struct {
+	conn  net.Conn
+	codec string
+	buf   bytes.Buffer
+}
+
+func DialUDPSender(addr string, codec string) (*UDPSender, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to dial %s: %w", addr, err)
+	}
+	return &UDPSender{
+		conn:  conn,
+		codec: codec,
+	}, nil
+}
+
+func (s *UDPSender) Write(p []byte) (int, error) {
+	s.buf.Write(p)
+
+	for {
+		n := s.nextPacketSize()
+		if n == 0 {
+			return len(p), nil
+		}
+		if _, err := s.conn.Write(s.buf.Next(n)); err != nil {
+			return 0, xerrors.Errorf("failed to send talkback audio: %w", err)
+		}
+	}
+}
+
+// nextPacketSize returns size of the next packet in buffer or 0 if packet is not complete yet
+func (s *UDPSender) nextPacketSize() int {
+	b := s.buf.Bytes()
+	if s.codec != "aac" {
+		if len(b) < maxDatagramSize {
+			return 0
+		}
+		return maxDatagramSize
+	}
+
+	// skip garbage until ADTS sync word
+	for len(b) >= 2 && !(b[0] == 0xff && b[1]&0xf0 == 0xf0) {
+		s.buf.Next(1)
+		b = s.buf.Bytes()
+	}
+	if len(b) < 7 {
+		return 0
+	}
+	frameLength := int(b[3]&0x03)<<11 | int(b[4])<<3 | int(b[5])>>5
+	if frameLength < 7 || len(b) < frameLength {
+		if frameLength < 7 {
+			s.buf.Next(1)
+		}
+		return 0
+	}
+	return frameLength
+}
+
+func (s *UDPSender) Close() error {
+	if rest := s.buf.Len(); rest > 0 && s.codec != "aac" {
+		_, _ = s.conn.Write(s.buf.Next(rest))
+	}
+	return s.conn.Close()
+}
@@ -0,0 +1,105 @@
+package talkback
+
+import (
+	"context"
+
+	"github.com/sawadashota/unifi-doorbell-chime/x/unifi"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+type Talkback struct {
+	r      Registry
+	c      Configuration
+	logger logrus.FieldLogger
+}
+
+type Registry interface {
+	AppLogger(app string) logrus.FieldLogger
+	UnifiClient() *unifi.Client
+}
+
+type Configuration interface {
+	StreamFFmpegPath() string
+	TalkbackEncoder() string
+	TalkbackAddress() string
+}
+
+func New(r Registry, c Configuration) *Talkback {
+	return &Talkback{
+		r:      r,
+		c:      c,
+		logger: r.AppLogger("talkback"),
+	}
+}
+
+func (t *Talkback) encoder() EncoderFactory {
+	switch t.c.TalkbackEncoder() {
+	case EncoderPCM:
+		return PCMEncoderFactory{}
+	default:
+		return FFmpegEncoderFactory{
+			Path:   t.c.StreamFFmpegPath(),
+			Logger: t.logger,
+		}
+	}
+}
+
+// Session forwards microphone audio of browser to the doorbell speaker
+type Session struct {
+	encoder Encoder
+	sender  *UDPSender
+}
+
+// Open starts talkback session to the doorbell. inputRate is sampling rate of PCM written to Session.
+func (t *Talkback) Open(ctx context.Context, doorbellID string, inputRate int) (*Session, error) {
+	target, err := t.r.UnifiClient().GetTalkbackTarget(ctx, doorbellID)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open talkback session: %w", err)
+	}
+
+	addr := target.Addr
+	if a := t.c.TalkbackAddress(); a != "" {
+		addr = a
+	}
+	codec := target.Format
+	if t.c.TalkbackEncoder() == EncoderPCM {
+		codec = "pcm_s16le"
+	}
+
+	sender, err := DialUDPSender(addr, codec)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open talkback session: %w", err)
+	}
+
+	encoder, err := t.encoder().NewEncoder(
+		ctx,
+		Format{SamplingRate: inputRate, Channels: 1},
+		Format{SamplingRate: target.SamplingRate, Channels: target.Channels},
+		codec,
+		sender,
+	)
+	if err != nil {
+		_ = sender.Close()
+		return nil, xerrors.Errorf("failed to open talkback session: %w", err)
+	}
+
+	t.logger.Infof("start talkback to %s (%s %dHz)", addr, codec, target.SamplingRate)
+	return &Session{
+		encoder: encoder,
+		sender:  sender,
+	}, nil
+}
+
+// Write accepts mono signed 16-bit little endian PCM
+func (s *Session) Write(p []byte) (int, error) {
+	return s.encoder.Write(p)
+}
+
+func (s *Session) Close() error {
+	if err := s.encoder.Close(); err != nil {
+		_ = s.sender.Close()
+		return xerrors.Errorf("failed to close encoder: %w", err)
+	}
+	return s.sender.Close()
+}
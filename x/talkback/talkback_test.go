@@ -0,0 +1,206 @@
+package talkback
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// datagrams records every datagram received by Receiver
+type datagrams struct {
+	mu   sync.Mutex
+	list [][]byte
+}
+
+func (d *datagrams) Write(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.list = append(d.list, append([]byte(nil), p...))
+	return len(p), nil
+}
+
+func (d *datagrams) wait(t *testing.T, n int) [][]byte {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		d.mu.Lock()
+		got := len(d.list)
+		list := d.list
+		d.mu.Unlock()
+		if got >= n {
+			return list
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("received %d datagrams, want %d", got, n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func listenReceiver(t *testing.T) (*Receiver, *datagrams) {
+	t.Helper()
+	d := new(datagrams)
+	r, err := ListenReceiver("127.0.0.1:0", d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = r.Close()
+	})
+	return r, d
+}
+
+// adtsFrame returns ADTS frame whose payload is filled with b
+func adtsFrame(payloadSize int, b byte) []byte {
+	frameLength := 7 + payloadSize
+	frame := []byte{
+		0xff, 0xf1, 0x50,
+		0x80 | byte(frameLength>>11)&0x03,
+		byte(frameLength >> 3),
+		byte(frameLength&0x07)<<5 | 0x1f,
+		0xfc,
+	}
+	return append(frame, bytes.Repeat([]byte{b}, payloadSize)...)
+}
+
+func TestUDPSenderADTS(t *testing.T) {
+	r, received := listenReceiver(t)
+
+	s, err := DialUDPSender(r.Addr().String(), "aac")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frames := [][]byte{adtsFrame(10, 0x01), adtsFrame(300, 0x02), adtsFrame(1, 0x03)}
+	var stream []byte
+	stream = append(stream, 0x00, 0x12) // garbage before sync word
+	for _, f := range frames {
+		stream = append(stream, f...)
+	}
+
+	// frames are split at arbitrary points like output of ffmpeg
+	for len(stream) > 0 {
+		n := 13
+		if n > len(stream) {
+			n = len(stream)
+		}
+		if _, err := s.Write(stream[:n]); err != nil {
+			t.Fatal(err)
+		}
+		stream = stream[n:]
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := received.wait(t, len(frames))
+	if len(got) != len(frames) {
+		t.Fatalf("received %d datagrams, want %d", len(got), len(frames))
+	}
+	for i := range frames {
+		if !bytes.Equal(got[i], frames[i]) {
+			t.Errorf("datagram %d is not ADTS frame %d: got %d bytes, want %d bytes", i, i, len(got[i]), len(frames[i]))
+		}
+	}
+	if packets, n := r.Stats(); packets != 3 || n != 7*3+311 {
+		t.Errorf("stats: got %d packets %d bytes", packets, n)
+	}
+}
+
+func TestPCMEncoderToReceiver(t *testing.T) {
+	r, received := listenReceiver(t)
+
+	s, err := DialUDPSender(r.Addr().String(), "pcm_s16le")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := PCMEncoderFactory{}.NewEncoder(
+		context.Background(),
+		Format{SamplingRate: 8000, Channels: 1},
+		Format{SamplingRate: 16000, Channels: 2},
+		"pcm_s16le",
+		s,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 1024 mono samples become 2048 stereo samples of 4 bytes
+	in := encodeS16LE(make([]int16, 1024), 1)
+	if _, err := e.Write(in); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := received.wait(t, 8)
+	for i, d := range got {
+		if len(d) != maxDatagramSize {
+			t.Errorf("datagram %d: got %d bytes, want %d", i, len(d), maxDatagramSize)
+		}
+	}
+}
+
+// encodePCM writes chunks to PCM encoder and returns output
+func encodePCM(t *testing.T, in, out Format, chunks [][]byte) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	e, err := PCMEncoderFactory{}.NewEncoder(context.Background(), in, out, "pcm_s16le", &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range chunks {
+		if _, err := e.Write(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestPCMEncoderOddChunks(t *testing.T) {
+	samples := make([]int16, 4410)
+	for i := range samples {
+		samples[i] = int16(i * 7)
+	}
+	pcm := encodeS16LE(samples, 1)
+
+	for _, tc := range []struct {
+		in, out int
+		want    int
+	}{
+		{in: 44100, out: 48000, want: 4800},
+		{in: 48000, out: 16000, want: 1470},
+		{in: 8000, out: 22050, want: 12156},
+	} {
+		in := Format{SamplingRate: tc.in, Channels: 1}
+		out := Format{SamplingRate: tc.out, Channels: 1}
+		whole := encodePCM(t, in, out, [][]byte{pcm})
+
+		// chunks of WebSocket messages split samples at odd bytes
+		var chunks [][]byte
+		for rest, size := pcm, 1; len(rest) > 0; size = size%13 + 2 {
+			if size > len(rest) {
+				size = len(rest)
+			}
+			chunks = append(chunks, rest[:size])
+			rest = rest[size:]
+		}
+		chunked := encodePCM(t, in, out, chunks)
+
+		if got := len(chunked) / 2; got != tc.want {
+			t.Errorf("%d to %d: got %d samples, want %d", tc.in, tc.out, got, tc.want)
+		}
+		if !bytes.Equal(chunked, whole) {
+			t.Errorf("%d to %d: output of odd chunks differs from output of whole audio", tc.in, tc.out)
+		}
+	}
+}
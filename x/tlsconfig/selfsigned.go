@@ -0,0 +1,267 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// file names in self-signed directory
+const (
+	CAFile         = "ca.pem"
+	caKeyFile      = "ca-key.pem"
+	serverFile     = "server.pem"
+	serverKeyFile  = "server-key.pem"
+	caValidity     = 10 * 365 * 24 * time.Hour
+	serverValidity = 397 * 24 * time.Hour
+	// server certificate is renewed when it expires within this duration
+	renewBefore = 30 * 24 * time.Hour
+)
+
+// SelfSigned returns server certificate issued by CA stored in dir.
+// CA is created once and kept so that devices trusting it keep working.
+// Server certificate is issued again when it is expiring or hosts are changed.
+func SelfSigned(dir string, hosts []string) (*tls.Certificate, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, xerrors.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	ca, caKey, err := loadOrCreateCA(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	certPath := filepath.Join(dir, serverFile)
+	keyPath := filepath.Join(dir, serverKeyFile)
+	if cert, err := tls.LoadX509KeyPair(certPath, keyPath); err == nil {
+		if leaf, err := x509.ParseCertificate(cert.Certificate[0]); err == nil && isReusable(leaf, ca, hosts) {
+			return &cert, nil
+		}
+	}
+
+	if err := issueServerCert(ca, caKey, hosts, certPath, keyPath); err != nil {
+		return nil, err
+	}
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load issued certificate: %w", err)
+	}
+	return &cert, nil
+}
+
+func isReusable(leaf, ca *x509.Certificate, hosts []string) bool {
+	if time.Until(leaf.NotAfter) < renewBefore {
+		return false
+	}
+	if leaf.CheckSignatureFrom(ca) != nil {
+		return false
+	}
+	dns, ips := splitHosts(hosts)
+	return equalStrings(leaf.DNSNames, dns) && equalIPs(leaf.IPAddresses, ips)
+}
+
+func loadOrCreateCA(dir string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certPath := filepath.Join(dir, CAFile)
+	keyPath := filepath.Join(dir, caKeyFile)
+
+	if pair, err := tls.LoadX509KeyPair(certPath, keyPath); err == nil {
+		ca, err := x509.ParseCertificate(pair.Certificate[0])
+		if err != nil {
+			return nil, nil, xerrors.Errorf("failed to parse %s: %w", certPath, err)
+		}
+		key, ok := pair.PrivateKey.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, nil, xerrors.Errorf("%s is not ECDSA key", keyPath)
+		}
+		return ca, key, nil
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to generate CA key: %w", err)
+	}
+	hostname, _ := os.Hostname()
+	tmpl := &x509.Certificate{
+		SerialNumber: serialNumber(),
+		Subject: pkix.Name{
+			Organization: []string{"unifi-doorbell-chime"},
+			CommonName:   "unifi-doorbell-chime CA " + hostname,
+		},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to create CA: %w", err)
+	}
+	if err := writePEM(certPath, "CERTIFICATE", der, 0644); err != nil {
+		return nil, nil, err
+	}
+	if err := writeKey(keyPath, key); err != nil {
+		return nil, nil, err
+	}
+
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to parse CA: %w", err)
+	}
+	return ca, key, nil
+}
+
+func issueServerCert(ca *x509.Certificate, caKey *ecdsa.PrivateKey, hosts []string, certPath, keyPath string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return xerrors.Errorf("failed to generate key: %w", err)
+	}
+
+	dns, ips := splitHosts(hosts)
+	tmpl := &x509.Certificate{
+		SerialNumber: serialNumber(),
+		Subject: pkix.Name{
+			Organization: []string{"unifi-doorbell-chime"},
+			CommonName:   "unifi-doorbell-chime",
+		},
+		NotBefore:   time.Now().Add(-time.Hour),
+		NotAfter:    time.Now().Add(serverValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:    dns,
+		IPAddresses: ips,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return xerrors.Errorf("failed to issue certificate: %w", err)
+	}
+	if err := writePEM(certPath, "CERTIFICATE", der, 0644); err != nil {
+		return err
+	}
+	return writeKey(keyPath, key)
+}
+
+func serialNumber() *big.Int {
+	n, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return big.NewInt(time.Now().UnixNano())
+	}
+	return n
+}
+
+func writeKey(path string, key *ecdsa.PrivateKey) error {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal key: %w", err)
+	}
+	return writePEM(path, "EC PRIVATE KEY", der, 0600)
+}
+
+func writePEM(path, typ string, der []byte, perm os.FileMode) error {
+	b := pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+	if err := ioutil.WriteFile(path, b, perm); err != nil {
+		return xerrors.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// splitHosts splits hosts to sorted DNS names and IP addresses
+func splitHosts(hosts []string) ([]string, []net.IP) {
+	var (
+		dns  []string
+		ips  []net.IP
+		seen = make(map[string]bool)
+	)
+	for _, h := range hosts {
+		if h == "" || seen[h] {
+			continue
+		}
+		seen[h] = true
+		if ip := net.ParseIP(h); ip != nil {
+			ips = append(ips, ip)
+			continue
+		}
+		dns = append(dns, h)
+	}
+	sort.Strings(dns)
+	sort.Slice(ips, func(i, j int) bool {
+		return ips[i].String() < ips[j].String()
+	})
+	return dns, ips
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	sort.Strings(a)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalIPs(a, b []net.IP) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	as := make([]string, 0, len(a))
+	for _, ip := range a {
+		as = append(as, ip.String())
+	}
+	bs := make([]string, 0, len(b))
+	for _, ip := range b {
+		bs = append(bs, ip.String())
+	}
+	sort.Strings(as)
+	sort.Strings(bs)
+	return equalStrings(as, bs)
+}
+
+// LocalHosts returns host names and addresses which the host is reached by on LAN
+func LocalHosts() []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if hostname, err := os.Hostname(); err == nil {
+		hosts = append(hosts, hostname)
+		if !containsDot(hostname) {
+			hosts = append(hosts, hostname+".local")
+		}
+	}
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return hosts
+	}
+	for _, a := range addrs {
+		if n, ok := a.(*net.IPNet); ok && !n.IP.IsLoopback() && !n.IP.IsLinkLocalUnicast() {
+			hosts = append(hosts, n.IP.String())
+		}
+	}
+	return hosts
+}
+
+func containsDot(s string) bool {
+	for _, c := range s {
+		if c == '.' {
+			return true
+		}
+	}
+	return false
+}
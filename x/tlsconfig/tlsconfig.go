@@ -0,0 +1,424 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+	"golang.org/x/xerrors"
+)
+
+// TLS modes
+const (
+	ModeOff        = "off"
+	ModeFiles      = "files"
+	ModeSelfSigned = "self-signed"
+	ModeACME       = "acme"
+)
+
+// Modes is list of available TLS modes
+var Modes = []string{ModeOff, ModeFiles, ModeSelfSigned, ModeACME}
+
+// Manager provides TLS config shared by API and frontend servers
+type Manager struct {
+	r      Registry
+	c      Configuration
+	logger logrus.FieldLogger
+
+	mu       sync.Mutex
+	key      string
+	config   *tls.Config
+	autocert *autocert.Manager
+}
+
+type Registry interface {
+	AppLogger(app string) logrus.FieldLogger
+}
+
+type Configuration interface {
+	TLSMode() string
+	TLSCertFile() string
+	TLSKeyFile() string
+	TLSSelfSignedDir() string
+	TLSSelfSignedHosts() []string
+	TLSACMEDirectoryURL() string
+	TLSACMEEmail() string
+	TLSACMEDomains() []string
+	TLSACMECacheDir() string
+	TLSACMEHTTPAddr() string
+	TLSHSTSMaxAgeSec() int
+}
+
+func New(r Registry, c Configuration) *Manager {
+	return &Manager{
+		r:      r,
+		c:      c,
+		logger: r.AppLogger("tls"),
+	}
+}
+
+// Enabled returns whether servers serve HTTPS
+func (m *Manager) Enabled() bool {
+	return m.c.TLSMode() != ModeOff
+}
+
+// Config returns TLS config of current settings. nil is returned when TLS is off.
+// Config is built again when settings of the mode are changed by config reload.
+func (m *Manager) Config() (*tls.Config, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mode := m.c.TLSMode()
+	key := m.configKey(mode)
+	if m.config != nil && m.key == key {
+		return m.config, nil
+	}
+
+	var (
+		config  *tls.Config
+		manager *autocert.Manager
+		err     error
+	)
+	switch mode {
+	case ModeOff:
+		config = nil
+	case ModeFiles:
+		config, err = m.filesConfig()
+	case ModeSelfSigned:
+		config, err = m.selfSignedConfig()
+	case ModeACME:
+		config, manager, err = m.acmeConfig()
+	default:
+		err = xerrors.Errorf("unknown TLS mode: %s", mode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m.key = key
+	m.config = config
+	m.autocert = manager
+	return config, nil
+}
+
+// ServerConfig returns TLS config for servers. nil is returned when TLS is off.
+// Each connection is served by Config so that settings changed by config reload take effect
+// without restart, except turning TLS on or off.
+func (m *Manager) ServerConfig() (*tls.Config, error) {
+	config, err := m.Config()
+	if err != nil || config == nil {
+		return config, err
+	}
+
+	server := newConfig()
+	server.NextProtos = config.NextProtos
+	server.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		current, err := m.Config()
+		if err != nil {
+			m.logger.Warnf("keep serving previous TLS config: %s", err)
+			return m.previousConfig(), nil
+		}
+		if current == nil {
+			return nil, xerrors.New("TLS is turned off by config reload. restart to serve HTTP")
+		}
+		return current, nil
+	}
+	return server, nil
+}
+
+// previousConfig returns config built last
+func (m *Manager) previousConfig() *tls.Config {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.config
+}
+
+// configKey returns mode and settings which the config of the mode is built from
+func (m *Manager) configKey(mode string) string {
+	switch mode {
+	case ModeFiles:
+		return fmt.Sprintf("%s %q %q", mode, m.c.TLSCertFile(), m.c.TLSKeyFile())
+	case ModeSelfSigned:
+		return fmt.Sprintf("%s %q %q", mode, m.c.TLSSelfSignedDir(), m.c.TLSSelfSignedHosts())
+	case ModeACME:
+		return fmt.Sprintf("%s %q %q %q %q", mode, m.c.TLSACMEDirectoryURL(), m.c.TLSACMEEmail(), m.c.TLSACMEDomains(), m.c.TLSACMECacheDir())
+	default:
+		return mode
+	}
+}
+
+func newConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+}
+
+// filesConfig loads certificate from files and loads it again when the files are updated
+// so that renewed certificate is served without restart
+func (m *Manager) filesConfig() (*tls.Config, error) {
+	kp := &keyPair{
+		certFile: m.c.TLSCertFile(),
+		keyFile:  m.c.TLSKeyFile(),
+		logger:   m.logger,
+	}
+	if _, err := kp.certificate(); err != nil {
+		return nil, err
+	}
+
+	config := newConfig()
+	config.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		return kp.certificate()
+	}
+	return config, nil
+}
+
+// selfSignedConfig issues self-signed certificate and issues it again when it is expiring
+// so that it is renewed without restart
+func (m *Manager) selfSignedConfig() (*tls.Config, error) {
+	ss := &selfSigned{
+		dir:    m.c.TLSSelfSignedDir(),
+		hosts:  m.c.TLSSelfSignedHosts(),
+		logger: m.logger,
+	}
+	if _, err := ss.certificate(); err != nil {
+		return nil, err
+	}
+	m.logger.Infof("serve self-signed certificate. trust %s on devices to avoid warnings", m.CAPath())
+
+	config := newConfig()
+	config.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		return ss.certificate()
+	}
+	return config, nil
+}
+
+func (m *Manager) acmeConfig() (*tls.Config, *autocert.Manager, error) {
+	domains := m.c.TLSACMEDomains()
+	if len(domains) == 0 {
+		return nil, nil, xerrors.New("no domain for ACME")
+	}
+	directoryURL := m.c.TLSACMEDirectoryURL()
+	if directoryURL == "" {
+		// acme.Client falls back to Let's Encrypt
+		return nil, nil, xerrors.New("no directory URL of ACME server")
+	}
+
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(m.c.TLSACMECacheDir()),
+		HostPolicy: autocert.HostWhitelist(domains...),
+		Email:      m.c.TLSACMEEmail(),
+		Client: &acme.Client{
+			DirectoryURL: directoryURL,
+		},
+	}
+
+	config := manager.TLSConfig()
+	config.MinVersion = tls.VersionTLS12
+	return config, manager, nil
+}
+
+// CAPath returns path of CA certificate which issues self-signed certificate
+func (m *Manager) CAPath() string {
+	return filepath.Join(m.c.TLSSelfSignedDir(), CAFile)
+}
+
+// Scheme returns URL scheme of servers
+func (m *Manager) Scheme() string {
+	if m.Enabled() {
+		return "https"
+	}
+	return "http"
+}
+
+// LocalURL returns base URL of server listening on port to be opened on this host
+func (m *Manager) LocalURL(port int) string {
+	host := "127.0.0.1"
+	if domains := m.c.TLSACMEDomains(); m.c.TLSMode() == ModeACME && len(domains) > 0 {
+		// ACME certificate is valid only for the domains
+		host = domains[0]
+	}
+	return fmt.Sprintf("%s://%s", m.Scheme(), net.JoinHostPort(host, strconv.Itoa(port)))
+}
+
+// HSTS adds Strict-Transport-Security header to responses over TLS
+func (m *Manager) HSTS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if maxAge := m.c.TLSHSTSMaxAgeSec(); r.TLS != nil && maxAge > 0 {
+			w.Header().Set("Strict-Transport-Security", fmt.Sprintf("max-age=%d", maxAge))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ListenAndServe serves HTTPS if svr has TLSConfig, otherwise HTTP
+func ListenAndServe(svr *http.Server) error {
+	if svr.TLSConfig != nil {
+		// certificate is given by TLSConfig
+		return svr.ListenAndServeTLS("", "")
+	}
+	return svr.ListenAndServe()
+}
+
+// Start serves HTTP-01 challenge of ACME if it is configured.
+// Otherwise it waits until ctx is done.
+func (m *Manager) Start(ctx context.Context) error {
+	addr := m.c.TLSACMEHTTPAddr()
+	if m.c.TLSMode() != ModeACME || addr == "" {
+		<-ctx.Done()
+		return nil
+	}
+
+	if _, err := m.Config(); err != nil {
+		return xerrors.Errorf("failed to configure ACME: %w", err)
+	}
+	svr := &http.Server{
+		Addr:    addr,
+		Handler: http.HandlerFunc(m.serveChallenge),
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		m.logger.Infof("start ACME challenge server. %s", addr)
+		if err := svr.ListenAndServe(); err != nil {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return svr.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		return xerrors.Errorf("exit ACME challenge server: %w", err)
+	}
+}
+
+// serveChallenge serves HTTP-01 challenge by ACME manager of current settings.
+// Anything else than challenge is redirected to HTTPS.
+func (m *Manager) serveChallenge(w http.ResponseWriter, r *http.Request) {
+	if _, err := m.Config(); err != nil {
+		m.logger.Warnf("failed to configure ACME: %s", err)
+	}
+	m.mu.Lock()
+	manager := m.autocert
+	m.mu.Unlock()
+
+	if manager == nil {
+		http.NotFound(w, r)
+		return
+	}
+	manager.HTTPHandler(nil).ServeHTTP(w, r)
+}
+
+type keyPair struct {
+	certFile string
+	keyFile  string
+	logger   logrus.FieldLogger
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func (k *keyPair) certificate() (*tls.Certificate, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	modTime, err := latestModTime(k.certFile, k.keyFile)
+	if err != nil {
+		if k.cert != nil {
+			// keep serving while files are being replaced
+			return k.cert, nil
+		}
+		return nil, err
+	}
+	if k.cert != nil && !modTime.After(k.modTime) {
+		return k.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(k.certFile, k.keyFile)
+	if err != nil {
+		if k.cert != nil {
+			k.logger.Warnf("keep serving previous certificate: %s", err)
+			return k.cert, nil
+		}
+		return nil, xerrors.Errorf("failed to load certificate: %w", err)
+	}
+	if k.cert != nil {
+		k.logger.Infof("%s is reloaded", k.certFile)
+	}
+	k.cert = &cert
+	k.modTime = modTime
+	return k.cert, nil
+}
+
+type selfSigned struct {
+	dir    string
+	hosts  []string
+	logger logrus.FieldLogger
+
+	mu       sync.Mutex
+	cert     *tls.Certificate
+	notAfter time.Time
+	retryAt  time.Time
+}
+
+// renewRetryInterval is interval of retrying failed renewal of self-signed certificate
+const renewRetryInterval = time.Hour
+
+// certificate returns self-signed certificate and issues it again within renewal window
+func (s *selfSigned) certificate() (*tls.Certificate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cert != nil && (time.Until(s.notAfter) >= renewBefore || time.Now().Before(s.retryAt)) {
+		return s.cert, nil
+	}
+
+	cert, err := SelfSigned(s.dir, append(LocalHosts(), s.hosts...))
+	if err == nil {
+		cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	}
+	if err != nil {
+		if s.cert != nil {
+			s.logger.Warnf("keep serving previous self-signed certificate: %s", err)
+			s.retryAt = time.Now().Add(renewRetryInterval)
+			return s.cert, nil
+		}
+		return nil, xerrors.Errorf("failed to prepare self-signed certificate: %w", err)
+	}
+	if s.cert != nil {
+		s.logger.Infof("self-signed certificate is renewed until %s", cert.Leaf.NotAfter.Format(time.RFC3339))
+	}
+	s.cert = cert
+	s.notAfter = cert.Leaf.NotAfter
+	return s.cert, nil
+}
+
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return latest, xerrors.Errorf("failed to stat %s: %w", f, err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,119 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+type registry struct{}
+
+func (registry) AppLogger(string) logrus.FieldLogger {
+	l := logrus.New()
+	l.SetOutput(ioutil.Discard)
+	return l
+}
+
+type configuration struct {
+	Configuration
+	mode  string
+	dir   string
+	hosts []string
+}
+
+func (c *configuration) TLSMode() string              { return c.mode }
+func (c *configuration) TLSSelfSignedDir() string     { return c.dir }
+func (c *configuration) TLSSelfSignedHosts() []string { return c.hosts }
+func (c *configuration) TLSACMEHTTPAddr() string      { return "" }
+
+func leaf(t *testing.T, config *tls.Config) *x509.Certificate {
+	t.Helper()
+	cert, err := config.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestServerConfigFollowsReload(t *testing.T) {
+	dir := t.TempDir()
+	c := &configuration{mode: ModeSelfSigned, dir: dir, hosts: []string{"doorbell.lan"}}
+	m := New(registry{}, c)
+
+	server, err := m.ServerConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := server.GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := leaf(t, first).VerifyHostname("doorbell.lan"); err != nil {
+		t.Fatal(err)
+	}
+
+	c.hosts = []string{"chime.lan"}
+	reloaded, err := server.GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := leaf(t, reloaded).VerifyHostname("chime.lan"); err != nil {
+		t.Errorf("certificate is not issued again for changed hosts: %s", err)
+	}
+
+	c.mode = ModeOff
+	if _, err := server.GetConfigForClient(&tls.ClientHelloInfo{}); err == nil {
+		t.Error("TLS is served after turned off")
+	}
+}
+
+func TestSelfSignedRenewal(t *testing.T) {
+	dir := t.TempDir()
+	ss := &selfSigned{dir: dir, logger: registry{}.AppLogger("tls")}
+	cert, err := ss.certificate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := ss.certificate(); again != cert {
+		t.Fatal("certificate is issued again before renewal window")
+	}
+
+	// certificate is expiring and so is the file, which is issued again if missing
+	expiring := ss.notAfter.Add(-serverValidity + renewBefore/2)
+	ss.notAfter = expiring
+	if err := os.Remove(filepath.Join(dir, serverFile)); err != nil {
+		t.Fatal(err)
+	}
+	renewed, err := ss.certificate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renewed == cert {
+		t.Fatal("certificate is not renewed within renewal window")
+	}
+	if !ss.notAfter.After(expiring) {
+		t.Errorf("expiry is not updated: %s", ss.notAfter)
+	}
+}
+
+func TestServeChallengeWithoutACME(t *testing.T) {
+	dir := t.TempDir()
+	m := New(registry{}, &configuration{mode: ModeSelfSigned, dir: dir})
+
+	w := httptest.NewRecorder()
+	m.serveChallenge(w, httptest.NewRequest("GET", "/.well-known/acme-challenge/token", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("got %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"golang.org/x/xerrors"
 )
 
+// ErrUnauthorized is returned when UniFi Protect rejects username or password
+var ErrUnauthorized = xerrors.New("username or password is rejected")
+
 func (c *Client) Authenticate() error {
 	u := c.baseURL()
 	u.Path = "/api/auth"
@@ -18,9 +21,13 @@ func (c *Client) Authenticate() error {
 		Password string `json:"password"`
 	}
 
+	password, err := c.c.UnifiPassword()
+	if err != nil {
+		return xerrors.Errorf("failed to get password of %s: %w", c.c.UnifiUsername(), err)
+	}
 	param := &requestParams{
 		Username: c.c.UnifiUsername(),
-		Password: c.c.UnifiPassword(),
+		Password: password,
 	}
 	var b bytes.Buffer
 	if err := json.NewEncoder(&b).Encode(param); err != nil {
@@ -41,6 +48,10 @@ func (c *Client) Authenticate() error {
 		}
 	}()
 
+	if res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden {
+		return xerrors.Errorf("failed to authenticate as %s: %w", c.c.UnifiUsername(), ErrUnauthorized)
+	}
+
 	token := res.Header.Get("Authorization")
 	if token == "" {
 		return xerrors.New("could not get Authorization Header from acquireCookie response authenticatedHeader")
@@ -159,6 +159,7 @@ type Bootstrap struct {
 	LastUpdateID   string        `json:"lastUpdateId"`
 	CloudPortalURL string        `json:"cloudPortalUrl"`
 	Viewers        []interface{} `json:"viewers"`
+	Chimes         []ChimeDevice `json:"chimes"`
 	Lights         []interface{} `json:"lights"`
 	Bridges        []interface{} `json:"bridges"`
 	Sensors        []interface{} `json:"sensors"`
@@ -188,6 +189,7 @@ type Camera struct {
 	IsAttemptingToConnect bool        `json:"isAttemptingToConnect"`
 	IsHidden              interface{} `json:"isHidden"`
 	LastMotion            int64       `json:"lastMotion"`
+	LastSmartDetect       int64       `json:"lastSmartDetect"`
 	MicVolume             int         `json:"micVolume"`
 	IsMicEnabled          bool        `json:"isMicEnabled"`
 	IsRecording           bool        `json:"isRecording"`
@@ -328,32 +330,36 @@ type Camera struct {
 		WifiStrength int `json:"wifiStrength"`
 	} `json:"stats"`
 	FeatureFlags struct {
-		CanAdjustIrLedLevel   bool `json:"canAdjustIrLedLevel"`
-		CanMagicZoom          bool `json:"canMagicZoom"`
-		CanOpticalZoom        bool `json:"canOpticalZoom"`
-		CanTouchFocus         bool `json:"canTouchFocus"`
-		HasAccelerometer      bool `json:"hasAccelerometer"`
-		HasAec                bool `json:"hasAec"`
-		HasBattery            bool `json:"hasBattery"`
-		HasBluetooth          bool `json:"hasBluetooth"`
-		HasChime              bool `json:"hasChime"`
-		HasExternalIr         bool `json:"hasExternalIr"`
-		HasIcrSensitivity     bool `json:"hasIcrSensitivity"`
-		HasLdc                bool `json:"hasLdc"`
-		HasLedIr              bool `json:"hasLedIr"`
-		HasLedStatus          bool `json:"hasLedStatus"`
-		HasLineIn             bool `json:"hasLineIn"`
-		HasMic                bool `json:"hasMic"`
-		HasPrivacyMask        bool `json:"hasPrivacyMask"`
-		HasRtc                bool `json:"hasRtc"`
-		HasSdCard             bool `json:"hasSdCard"`
-		HasSpeaker            bool `json:"hasSpeaker"`
-		HasWifi               bool `json:"hasWifi"`
-		HasHdr                bool `json:"hasHdr"`
-		HasAutoICROnly        bool `json:"hasAutoICROnly"`
-		HasMotionZones        bool `json:"hasMotionZones"`
-		HasLcdScreen          bool `json:"hasLcdScreen"`
-		HasNewMotionAlgorithm bool `json:"hasNewMotionAlgorithm"`
+		CanAdjustIrLedLevel   bool     `json:"canAdjustIrLedLevel"`
+		CanMagicZoom          bool     `json:"canMagicZoom"`
+		CanOpticalZoom        bool     `json:"canOpticalZoom"`
+		CanTouchFocus         bool     `json:"canTouchFocus"`
+		HasAccelerometer      bool     `json:"hasAccelerometer"`
+		HasAec                bool     `json:"hasAec"`
+		HasBattery            bool     `json:"hasBattery"`
+		HasBluetooth          bool     `json:"hasBluetooth"`
+		HasChime              bool     `json:"hasChime"`
+		HasExternalIr         bool     `json:"hasExternalIr"`
+		HasIcrSensitivity     bool     `json:"hasIcrSensitivity"`
+		HasLdc                bool     `json:"hasLdc"`
+		HasLedIr              bool     `json:"hasLedIr"`
+		HasLedStatus          bool     `json:"hasLedStatus"`
+		HasLineIn             bool     `json:"hasLineIn"`
+		HasMic                bool     `json:"hasMic"`
+		HasPrivacyMask        bool     `json:"hasPrivacyMask"`
+		HasRtc                bool     `json:"hasRtc"`
+		HasSdCard             bool     `json:"hasSdCard"`
+		HasSpeaker            bool     `json:"hasSpeaker"`
+		HasWifi               bool     `json:"hasWifi"`
+		HasHdr                bool     `json:"hasHdr"`
+		HasAutoICROnly        bool     `json:"hasAutoICROnly"`
+		HasMotionZones        bool     `json:"hasMotionZones"`
+		HasLcdScreen          bool     `json:"hasLcdScreen"`
+		HasNewMotionAlgorithm bool     `json:"hasNewMotionAlgorithm"`
+		IsDoorbell            bool     `json:"isDoorbell"`
+		HasPackageCamera      bool     `json:"hasPackageCamera"`
+		HasSmartDetect        bool     `json:"hasSmartDetect"`
+		SmartDetectTypes      []string `json:"smartDetectTypes"`
 	} `json:"featureFlags"`
 	PirSettings struct {
 		PirSensitivity            int `json:"pirSensitivity"`
@@ -362,6 +368,9 @@ type Camera struct {
 		TimelapseTransferInterval int `json:"timelapseTransferInterval"`
 	} `json:"pirSettings"`
 	LcdMessage struct {
+		Type    string `json:"type"`
+		Text    string `json:"text"`
+		ResetAt *int64 `json:"resetAt"`
 	} `json:"lcdMessage"`
 	WifiConnectionState struct {
 		Channel        interface{} `json:"channel"`
@@ -381,12 +390,10 @@ type Camera struct {
 	ModelKey     string `json:"modelKey"`
 }
 
-func (c Camera) isDoorbell() bool {
-	return c.Type == "UVC G4 Doorbell"
-}
-
 type Cameras []Camera
 
+var ErrDoorbellNotFound = xerrors.New("doorbell is not found")
+
 type Doorbell Camera
 type Doorbells []Doorbell
 
@@ -395,20 +402,48 @@ func (c *Client) GetDoorbells(ctx context.Context) (Doorbells, error) {
 	if err != nil {
 		return nil, xerrors.Errorf("failed to get doorbells: %w", err)
 	}
+	return c.FilterDoorbells(b), nil
+}
 
+// FilterDoorbells returns watched doorbells in b
+func (c *Client) FilterDoorbells(b *Bootstrap) Doorbells {
 	var ds Doorbells
-	for _, c := range b.Cameras {
-		if c.IsManaged && c.isDoorbell() {
-			ds = append(ds, Doorbell(c))
+	for _, camera := range b.Cameras {
+		if c.IsWatchedDoorbell(camera) {
+			ds = append(ds, Doorbell(camera))
+		}
+	}
+	return ds
+}
+
+// IsWatchedDoorbell reports whether the camera is a doorbell watched by config.
+// If doorbells.include is set, only cameras listed in it are watched even if they aren't detected as doorbell.
+// Otherwise every detected doorbell is watched. Cameras listed in doorbells.exclude are never watched.
+func (c *Client) IsWatchedDoorbell(camera Camera) bool {
+	if !camera.IsManaged || camera.listed(c.c.DoorbellsExclude()) {
+		return false
+	}
+	include := c.c.DoorbellsInclude()
+	if len(include) > 0 {
+		return camera.listed(include)
+	}
+	return camera.isDoorbell()
+}
+
+func (ds Doorbells) find(doorbellID string) (*Doorbell, error) {
+	for _, d := range ds {
+		if d.ID == doorbellID {
+			return &d, nil
 		}
 	}
-	return ds, nil
+	return nil, xerrors.Errorf("doorbell %s: %w", doorbellID, ErrDoorbellNotFound)
 }
 
-func (d Doorbell) DoesRung(oldStates Doorbells) bool {
-	for _, old := range oldStates {
-		if d.Name == old.Name {
-			return d.LastRing > old.LastRing
+// listed reports whether the camera is listed by ID or name
+func (c Camera) listed(list []string) bool {
+	for _, v := range list {
+		if v == c.ID || v == c.Name {
+			return true
 		}
 	}
 	return false
@@ -425,3 +460,11 @@ func (c *Client) GetBootstrap(ctx context.Context) (*Bootstrap, error) {
 	c.logger.Debugln("get bootstrap successfully")
 	return &bootstrap, nil
 }
+
+func (c *Client) GetDoorbell(ctx context.Context, doorbellID string) (*Doorbell, error) {
+	ds, err := c.GetDoorbells(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get doorbell: %w", err)
+	}
+	return ds.find(doorbellID)
+}
@@ -0,0 +1,216 @@
+package unifi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+// DefaultMaxAge is how old cached bootstrap can be for consumers which don't need the latest.
+// Listener refreshes it more often than this while it is running.
+const DefaultMaxAge = 5 * time.Second
+
+// Cache holds the latest bootstrap shared by listener, API and others,
+// and publishes changes of cameras to subscribers whenever it is refreshed
+type Cache struct {
+	c      *Client
+	logger logrus.FieldLogger
+
+	mu        sync.RWMutex
+	bootstrap *Bootstrap
+	fetchedAt time.Time
+	subs      map[*subscriber]struct{}
+
+	// fetchMu serializes fetching so that concurrent consumers share a single fetch
+	fetchMu sync.Mutex
+}
+
+func newCache(c *Client) *Cache {
+	return &Cache{
+		c:      c,
+		logger: c.r.AppLogger("unifi-cache"),
+		subs:   make(map[*subscriber]struct{}),
+	}
+}
+
+// Refresh fetches bootstrap and publishes changes of cameras since the last one.
+// It is shared by consumers and must not be modified.
+func (c *Cache) Refresh(ctx context.Context) (*Bootstrap, error) {
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+	return c.refresh(ctx)
+}
+
+func (c *Cache) refresh(ctx context.Context) (*Bootstrap, error) {
+	b, err := c.c.GetBootstrap(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to refresh bootstrap: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// first bootstrap is baseline. every camera would be reported as added otherwise
+	if c.bootstrap != nil {
+		if changes := Diff(c.bootstrap.Cameras, b.Cameras); len(changes) > 0 {
+			c.publish(changes)
+		}
+	}
+	c.bootstrap = b
+	c.fetchedAt = time.Now()
+	return b, nil
+}
+
+// update replaces the camera in cached bootstrap with the one responded by UniFi Protect after changing it
+func (c *Cache) update(camera Camera) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.bootstrap == nil {
+		return
+	}
+
+	b := *c.bootstrap
+	b.Cameras = make(Cameras, len(c.bootstrap.Cameras))
+	copy(b.Cameras, c.bootstrap.Cameras)
+	for i := range b.Cameras {
+		if b.Cameras[i].ID == camera.ID {
+			b.Cameras[i] = camera
+			if changes := Diff(c.bootstrap.Cameras, b.Cameras); len(changes) > 0 {
+				c.publish(changes)
+			}
+			c.bootstrap = &b
+			return
+		}
+	}
+}
+
+// publish delivers changes to every subscriber. c.mu must be held.
+// Slow subscribers never block refreshing; changes are merged until they receive instead.
+func (c *Cache) publish(changes []Change) {
+	c.logger.Debugf("publish changes of %d cameras", len(changes))
+	for sub := range c.subs {
+		sub.add(changes)
+	}
+}
+
+// subscriber holds changes which are not received yet
+type subscriber struct {
+	mu      sync.Mutex
+	pending []Change
+	// notify has a value while pending has changes
+	notify chan struct{}
+}
+
+func (s *subscriber) add(changes []Change) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = mergeChanges(s.pending, changes)
+	if len(s.pending) == 0 {
+		return
+	}
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscriber) take() []Change {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changes := s.pending
+	s.pending = nil
+	return changes
+}
+
+// Subscribe returns channel receiving changes of cameras on every refresh and function to stop subscription.
+// Changes are never dropped. Those published while the subscriber is busy are merged per camera
+// and received at once.
+func (c *Cache) Subscribe() (<-chan []Change, func()) {
+	sub := &subscriber{notify: make(chan struct{}, 1)}
+	ch := make(chan []Change)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	c.mu.Lock()
+	c.subs[sub] = struct{}{}
+	c.mu.Unlock()
+
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			case <-sub.notify:
+			}
+			changes := sub.take()
+			if len(changes) == 0 {
+				// changed back before taken
+				continue
+			}
+			select {
+			case <-done:
+				return
+			case ch <- changes:
+			}
+		}
+	}()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			c.mu.Lock()
+			delete(c.subs, sub)
+			c.mu.Unlock()
+			close(done)
+			<-stopped
+			close(ch)
+		})
+	}
+}
+
+// Bootstrap returns bootstrap fetched within maxAge, or refreshes it.
+// It is shared by consumers and must not be modified.
+func (c *Cache) Bootstrap(ctx context.Context, maxAge time.Duration) (*Bootstrap, error) {
+	if b := c.cached(maxAge); b != nil {
+		return b, nil
+	}
+
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+	// another consumer may have refreshed it while waiting
+	if b := c.cached(maxAge); b != nil {
+		return b, nil
+	}
+	return c.refresh(ctx)
+}
+
+func (c *Cache) cached(maxAge time.Duration) *Bootstrap {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.bootstrap == nil || time.Since(c.fetchedAt) > maxAge {
+		return nil
+	}
+	return c.bootstrap
+}
+
+// Doorbells returns watched doorbells in bootstrap fetched within maxAge
+func (c *Cache) Doorbells(ctx context.Context, maxAge time.Duration) (Doorbells, error) {
+	b, err := c.Bootstrap(ctx, maxAge)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get doorbells: %w", err)
+	}
+	return c.c.FilterDoorbells(b), nil
+}
+
+// Doorbell returns the watched doorbell in bootstrap fetched within maxAge
+func (c *Cache) Doorbell(ctx context.Context, doorbellID string, maxAge time.Duration) (*Doorbell, error) {
+	ds, err := c.Doorbells(ctx, maxAge)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get doorbell: %w", err)
+	}
+	return ds.find(doorbellID)
+}
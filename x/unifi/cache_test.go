@@ -0,0 +1,72 @@
+package unifi
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestCache() *Cache {
+	l := logrus.New()
+	l.SetOutput(ioutil.Discard)
+	return &Cache{
+		logger: l,
+		subs:   make(map[*subscriber]struct{}),
+	}
+}
+
+func TestCacheSubscribeNeverDropsChanges(t *testing.T) {
+	c := newTestCache()
+	changes, unsubscribe := c.Subscribe()
+	defer unsubscribe()
+
+	// subscriber doesn't receive while cameras are refreshed many times
+	prev := &Camera{ID: "door"}
+	for i := 1; i <= 100; i++ {
+		cur := &Camera{ID: "door", LastRing: uint64(i)}
+		c.mu.Lock()
+		c.publish([]Change{{Previous: prev, Current: cur, Fields: DiffCamera(prev, cur)}})
+		c.mu.Unlock()
+		prev = cur
+	}
+
+	// changes taken before the rest are received first, and the rest are merged
+	var last uint64
+	for last < 100 {
+		select {
+		case cs := <-changes:
+			if len(cs) != 1 || cs[0].Previous.LastRing != last {
+				t.Fatalf("changes since lastRing %d are dropped: %+v", last, cs)
+			}
+			last = cs[0].Current.LastRing
+		case <-time.After(time.Second):
+			t.Fatalf("changes since lastRing %d are dropped", last)
+		}
+	}
+
+	select {
+	case cs := <-changes:
+		t.Errorf("merged changes are received again: %+v", cs)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCacheUnsubscribe(t *testing.T) {
+	c := newTestCache()
+	changes, unsubscribe := c.Subscribe()
+
+	c.mu.Lock()
+	c.publish([]Change{{Current: &Camera{ID: "door"}}})
+	c.mu.Unlock()
+	unsubscribe()
+	unsubscribe()
+
+	if _, ok := <-changes; ok {
+		t.Error("channel is not closed")
+	}
+	if len(c.subs) != 0 {
+		t.Error("subscriber is not removed")
+	}
+}
@@ -0,0 +1,56 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/xerrors"
+)
+
+// ChimeDevice is UniFi Chime paired with doorbells
+type ChimeDevice struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	IsConnected bool     `json:"isConnected"`
+	CameraIDs   []string `json:"cameraIds"`
+}
+
+// ErrChimeTestUnsupported is returned if no chime of doorbell can be rung remotely
+var ErrChimeTestUnsupported = xerrors.New("no UniFi Chime is paired. mechanical and digital chimes can be tested only by pressing doorbell")
+
+// SetChimeDuration changes chime of the doorbell. See ChimeDurationNone and others for the values.
+func (c *Client) SetChimeDuration(ctx context.Context, doorbellID string, durationMs int) (*Doorbell, error) {
+	d, err := c.UpdateDoorbellSettings(ctx, doorbellID, DoorbellSettings{ChimeDurationMs: &durationMs})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to set chime: %w", err)
+	}
+	return d, nil
+}
+
+// PlayChime rings UniFi Chimes paired with the doorbell.
+// Mechanical and digital chimes wired to doorbell can't be rung remotely.
+func (c *Client) PlayChime(ctx context.Context, doorbellID string) error {
+	b, err := c.cache.Bootstrap(ctx, DefaultMaxAge)
+	if err != nil {
+		return xerrors.Errorf("failed to play chime: %w", err)
+	}
+
+	played := 0
+	for _, chime := range b.Chimes {
+		if !containsString(chime.CameraIDs, doorbellID) {
+			continue
+		}
+
+		u := c.baseURL()
+		u.Path = "/api/chimes/" + chime.ID + "/play-speaker"
+		if err := c.jsonRequest(ctx, http.MethodPost, u, nil, nil); err != nil {
+			return xerrors.Errorf("failed to play chime %s: %w", chime.Name, err)
+		}
+		c.logger.Debugf("play chime %s successfully", chime.Name)
+		played++
+	}
+	if played == 0 {
+		return xerrors.Errorf("doorbell %s: %w", doorbellID, ErrChimeTestUnsupported)
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ type Client struct {
 
 	httpclient          *http.Client
 	authenticatedHeader http.Header
+	cache               *Cache
 }
 
 type Registry interface {
@@ -23,22 +24,36 @@ type Registry interface {
 type Configuration interface {
 	UnifiIp() string
 	UnifiUsername() string
-	UnifiPassword() string
+	UnifiPassword() (string, error)
+	DoorbellsInclude() []string
+	DoorbellsExclude() []string
 }
 
 func NewClient(r Registry, config Configuration, httpclient *http.Client) *Client {
-	return &Client{
+	c := &Client{
 		c:          config,
 		r:          r,
 		httpclient: httpclient,
 		logger:     r.AppLogger("unifi-client"),
 	}
+	c.cache = newCache(c)
+	return c
+}
+
+// Cache returns bootstrap cache shared by consumers of the client
+func (c *Client) Cache() *Cache {
+	return c.cache
+}
+
+// Addr returns host:port of UniFi Protect
+func (c *Client) Addr() string {
+	return c.c.UnifiIp() + ":7443"
 }
 
 func (c *Client) baseURL() *url.URL {
 	u := &url.URL{
 		Scheme: "https",
-		Host:   c.c.UnifiIp() + ":7443",
+		Host:   c.Addr(),
 	}
 	return u
 }
@@ -0,0 +1,173 @@
+package unifi
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldChange is a changed field of camera. Field is JSON path, e.g. "lcdMessage.text"
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// Change is difference of a camera between two bootstraps.
+// Previous is nil if the camera is added, and Current is nil if it is removed.
+type Change struct {
+	Previous *Camera
+	Current  *Camera
+	Fields   []FieldChange
+}
+
+// ID returns ID of the changed camera
+func (c Change) ID() string {
+	if c.Current != nil {
+		return c.Current.ID
+	}
+	return c.Previous.ID
+}
+
+// Added reports whether the camera is added
+func (c Change) Added() bool {
+	return c.Previous == nil
+}
+
+// Removed reports whether the camera is removed
+func (c Change) Removed() bool {
+	return c.Current == nil
+}
+
+// Field returns change of the field
+func (c Change) Field(field string) (FieldChange, bool) {
+	for _, f := range c.Fields {
+		if f.Field == field {
+			return f, true
+		}
+	}
+	return FieldChange{}, false
+}
+
+// Changed reports whether any of fields is changed
+func (c Change) Changed(fields ...string) bool {
+	for _, field := range fields {
+		if _, ok := c.Field(field); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff returns changes of cameras from previous to current.
+// Unchanged cameras are not included.
+func Diff(previous, current Cameras) []Change {
+	old := make(map[string]*Camera, len(previous))
+	for i := range previous {
+		old[previous[i].ID] = &previous[i]
+	}
+
+	var changes []Change
+	for i := range current {
+		cur := &current[i]
+		prev, ok := old[cur.ID]
+		if !ok {
+			changes = append(changes, Change{Current: cur})
+			continue
+		}
+		delete(old, cur.ID)
+
+		if fields := DiffCamera(prev, cur); len(fields) > 0 {
+			changes = append(changes, Change{Previous: prev, Current: cur, Fields: fields})
+		}
+	}
+	for i := range previous {
+		if _, ok := old[previous[i].ID]; ok {
+			changes = append(changes, Change{Previous: &previous[i]})
+		}
+	}
+	return changes
+}
+
+// volatileFields change on every bootstrap without anything happening to the camera.
+// They are not compared not to publish every camera on every refresh.
+var volatileFields = map[string]bool{
+	"lastSeen":             true,
+	"phyRate":              true,
+	"apRssi":               true,
+	"stats":                true,
+	"wiredConnectionState": true,
+	"wifiConnectionState":  true,
+}
+
+// DiffCamera returns changed fields from previous to current except volatile ones
+func DiffCamera(previous, current *Camera) []FieldChange {
+	var fields []FieldChange
+	diffValue("", reflect.ValueOf(*previous), reflect.ValueOf(*current), &fields)
+	return fields
+}
+
+// diffValue walks fields of structs and compares others as a whole
+func diffValue(path string, old, cur reflect.Value, fields *[]FieldChange) {
+	if old.Kind() != reflect.Struct {
+		if !reflect.DeepEqual(old.Interface(), cur.Interface()) {
+			*fields = append(*fields, FieldChange{
+				Field: path,
+				Old:   old.Interface(),
+				New:   cur.Interface(),
+			})
+		}
+		return
+	}
+
+	t := old.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if path != "" {
+			name = path + "." + name
+		}
+		if volatileFields[name] {
+			continue
+		}
+		diffValue(name, old.Field(i), cur.Field(i), fields)
+	}
+}
+
+// mergeChanges returns pending followed by changes where changes of the same camera are merged
+// into one from the earliest previous to the latest current
+func mergeChanges(pending, changes []Change) []Change {
+	index := make(map[string]int, len(pending))
+	for i, c := range pending {
+		index[c.ID()] = i
+	}
+
+	for _, c := range changes {
+		i, ok := index[c.ID()]
+		if !ok {
+			index[c.ID()] = len(pending)
+			pending = append(pending, c)
+			continue
+		}
+		merged := Change{Previous: pending[i].Previous, Current: c.Current}
+		if merged.Previous != nil && merged.Current != nil {
+			merged.Fields = DiffCamera(merged.Previous, merged.Current)
+		}
+		pending[i] = merged
+	}
+
+	// camera added and removed, or changed back, is not changed
+	merged := pending[:0]
+	for _, c := range pending {
+		if c.Previous == nil && c.Current == nil || c.Previous != nil && c.Current != nil && len(c.Fields) == 0 {
+			continue
+		}
+		merged = append(merged, c)
+	}
+	return merged
+}
@@ -0,0 +1,60 @@
+package unifi
+
+import "testing"
+
+func TestDiffCameraIgnoresVolatileFields(t *testing.T) {
+	prev := Camera{ID: "door", LastSeen: 1000}
+	cur := prev
+	cur.LastSeen = 2000
+	cur.PhyRate = 100
+	cur.Stats.RxBytes = 4096
+	cur.Stats.Video.RecordingEnd = 2000
+	cur.WiredConnectionState.PhyRate = 1000
+
+	if fields := DiffCamera(&prev, &cur); len(fields) != 0 {
+		t.Errorf("volatile fields are compared: %v", fields)
+	}
+
+	cur.LastRing = 2000
+	fields := DiffCamera(&prev, &cur)
+	if len(fields) != 1 || fields[0].Field != "lastRing" {
+		t.Errorf("got %v, want lastRing", fields)
+	}
+}
+
+func TestMergeChanges(t *testing.T) {
+	door0 := &Camera{ID: "door"}
+	door1 := &Camera{ID: "door", LastRing: 1}
+	door2 := &Camera{ID: "door", LastRing: 2}
+	porch := &Camera{ID: "porch"}
+
+	ring := func(prev, cur *Camera) Change {
+		return Change{Previous: prev, Current: cur, Fields: DiffCamera(prev, cur)}
+	}
+
+	t.Run("latest of changed camera", func(t *testing.T) {
+		merged := mergeChanges([]Change{ring(door0, door1)}, []Change{ring(door1, door2), {Current: porch}})
+		if len(merged) != 2 {
+			t.Fatalf("got %d changes, want 2", len(merged))
+		}
+		if merged[0].Previous != door0 || merged[0].Current != door2 || !merged[0].Changed("lastRing") {
+			t.Errorf("ring is not merged: %+v", merged[0])
+		}
+		if !merged[1].Added() {
+			t.Errorf("added camera is lost: %+v", merged[1])
+		}
+	})
+
+	t.Run("added and removed", func(t *testing.T) {
+		if merged := mergeChanges([]Change{{Current: porch}}, []Change{{Previous: porch}}); len(merged) != 0 {
+			t.Errorf("got %+v, want nothing", merged)
+		}
+	})
+
+	t.Run("changed and removed", func(t *testing.T) {
+		merged := mergeChanges([]Change{ring(door0, door1)}, []Change{{Previous: door1}})
+		if len(merged) != 1 || !merged[0].Removed() || merged[0].Previous != door0 {
+			t.Errorf("got %+v, want removal from the first", merged)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package unifi
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const discoveryPort = 10001
+
+var discoveryRequest = []byte{0x01, 0x00, 0x00, 0x00}
+
+// discovery TLV types
+const (
+	discoveryTypeHardwareAddr = 0x01
+	discoveryTypeAddress      = 0x02
+	discoveryTypeFirmware     = 0x03
+	discoveryTypeHostname     = 0x0b
+	discoveryTypePlatform     = 0x0c
+	discoveryTypeModel        = 0x14
+	discoveryTypeModelLong    = 0x15
+)
+
+// DiscoveredDevice is UniFi device answered to UniFi discovery protocol
+type DiscoveredDevice struct {
+	IP       net.IP
+	Mac      net.HardwareAddr
+	Hostname string
+	Model    string
+	Platform string
+	Firmware string
+}
+
+// Discover broadcasts UniFi discovery request on UDP 10001 and collects answers until timeout
+func Discover(ctx context.Context, timeout time.Duration) ([]DiscoveredDevice, error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4zero})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to listen UDP: %w", err)
+	}
+	defer conn.Close()
+
+	dst := &net.UDPAddr{IP: net.IPv4bcast, Port: discoveryPort}
+	if _, err := conn.WriteToUDP(discoveryRequest, dst); err != nil {
+		return nil, xerrors.Errorf("failed to broadcast discovery request: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, xerrors.Errorf("failed to set deadline: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var devices []DiscoveredDevice
+	buf := make([]byte, 2048)
+	for {
+		n, from, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				return devices, nil
+			}
+			return devices, xerrors.Errorf("failed to read discovery response: %w", err)
+		}
+
+		d, ok := parseDiscoveryResponse(buf[:n])
+		if !ok {
+			continue
+		}
+		if d.IP == nil {
+			d.IP = from.IP
+		}
+		if seen[d.IP.String()] {
+			continue
+		}
+		seen[d.IP.String()] = true
+		devices = append(devices, d)
+	}
+}
+
+func parseDiscoveryResponse(b []byte) (DiscoveredDevice, bool) {
+	var d DiscoveredDevice
+	// version(1) command(1) length(2)
+	if len(b) < 4 || b[0] != 0x01 || b[1] != 0x00 {
+		return d, false
+	}
+	body := b[4:]
+	if l := int(binary.BigEndian.Uint16(b[2:4])); l < len(body) {
+		body = body[:l]
+	}
+
+	for len(body) >= 3 {
+		t := body[0]
+		l := int(binary.BigEndian.Uint16(body[1:3]))
+		if len(body) < 3+l {
+			break
+		}
+		v := body[3 : 3+l]
+		body = body[3+l:]
+
+		switch t {
+		case discoveryTypeHardwareAddr:
+			if l == 6 {
+				d.Mac = append(net.HardwareAddr(nil), v...)
+			}
+		case discoveryTypeAddress:
+			if l == 10 {
+				d.Mac = append(net.HardwareAddr(nil), v[:6]...)
+				d.IP = append(net.IP(nil), v[6:10]...)
+			}
+		case discoveryTypeFirmware:
+			d.Firmware = string(v)
+		case discoveryTypeHostname:
+			d.Hostname = string(v)
+		case discoveryTypePlatform:
+			d.Platform = string(v)
+		case discoveryTypeModel, discoveryTypeModelLong:
+			if d.Model == "" || t == discoveryTypeModelLong {
+				d.Model = string(v)
+			}
+		}
+	}
+	return d, d.IP != nil || d.Mac != nil
+}
@@ -0,0 +1,47 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// SmartDetectTypePackage is smart detect type of packages detected by package camera of doorbell
+const SmartDetectTypePackage = "package"
+
+// SmartDetectEvent is smart detection recorded by UniFi Protect
+type SmartDetectEvent struct {
+	ID     string `json:"id"`
+	Type   string `json:"type"`
+	Camera string `json:"camera"`
+	// Start and End are unix time in milliseconds. End is nil while the object is still detected.
+	Start            int64    `json:"start"`
+	End              *int64   `json:"end"`
+	SmartDetectTypes []string `json:"smartDetectTypes"`
+}
+
+// Detects reports whether the event detected objects of smart detect type t
+func (e SmartDetectEvent) Detects(t string) bool {
+	return containsString(e.SmartDetectTypes, t)
+}
+
+// GetSmartDetectEvents returns smart detections of the camera between start and end
+func (c *Client) GetSmartDetectEvents(ctx context.Context, cameraID string, start, end time.Time) ([]SmartDetectEvent, error) {
+	u := c.baseURL()
+	u.Path = "/api/events"
+	q := u.Query()
+	q.Set("cameras", cameraID)
+	q.Set("types", "smartDetectZone")
+	q.Set("start", strconv.FormatInt(start.UnixNano()/int64(time.Millisecond), 10))
+	q.Set("end", strconv.FormatInt(end.UnixNano()/int64(time.Millisecond), 10))
+	u.RawQuery = q.Encode()
+
+	var events []SmartDetectEvent
+	if err := c.jsonRequest(ctx, http.MethodGet, u, nil, &events); err != nil {
+		return nil, xerrors.Errorf("failed to get smart detect events: %w", err)
+	}
+	return events, nil
+}
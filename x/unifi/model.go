@@ -0,0 +1,48 @@
+package unifi
+
+// DoorbellModel is a doorbell released by Ubiquiti.
+// Older UniFi Protect doesn't tell everything about the model by feature flags.
+type DoorbellModel struct {
+	// Type is type of camera reported by UniFi Protect
+	Type string
+	// HasPackageCamera is true if doorbell has second camera looking down at packages
+	HasPackageCamera bool
+}
+
+// DoorbellModels is list of known doorbell models
+var DoorbellModels = []DoorbellModel{
+	{Type: "UVC G4 Doorbell"},
+	{Type: "UVC G4 Doorbell Pro", HasPackageCamera: true},
+	{Type: "UVC G4 Doorbell Pro PoE", HasPackageCamera: true},
+	{Type: "UVC G6 Doorbell"},
+	{Type: "UVC G6 Entry"},
+}
+
+func doorbellModel(typ string) (DoorbellModel, bool) {
+	for _, m := range DoorbellModels {
+		if m.Type == typ {
+			return m, true
+		}
+	}
+	return DoorbellModel{}, false
+}
+
+// isDoorbell reports whether the camera is a doorbell by feature flags or known models.
+// Only doorbells have chime or LCD screen, so they detect models released after this table.
+func (c Camera) isDoorbell() bool {
+	f := c.FeatureFlags
+	if f.IsDoorbell || f.HasChime || f.HasLcdScreen {
+		return true
+	}
+	_, ok := doorbellModel(c.Type)
+	return ok
+}
+
+// HasPackageCamera reports whether the doorbell has package camera like G4 Doorbell Pro
+func (d Doorbell) HasPackageCamera() bool {
+	if d.FeatureFlags.HasPackageCamera {
+		return true
+	}
+	m, _ := doorbellModel(d.Type)
+	return m.HasPackageCamera
+}
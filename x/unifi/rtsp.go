@@ -0,0 +1,52 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"golang.org/x/xerrors"
+)
+
+var ErrRTSPDisabled = xerrors.New("RTSP is not enabled on any channel")
+
+const defaultRTSPPort = 7447
+
+// GetRTSPURL returns RTSP URL of the doorbell's channel which is enabled RTSP and has the lowest resolution
+func (c *Client) GetRTSPURL(ctx context.Context, doorbellID string) (string, error) {
+	b, err := c.cache.Bootstrap(ctx, DefaultMaxAge)
+	if err != nil {
+		return "", xerrors.Errorf("failed to get RTSP URL: %w", err)
+	}
+
+	for _, camera := range b.Cameras {
+		if camera.ID != doorbellID {
+			continue
+		}
+
+		alias := ""
+		width := 0
+		for _, ch := range camera.Channels {
+			a, ok := ch.RtspAlias.(string)
+			if !ch.Enabled || !ch.IsRtspEnabled || !ok || a == "" {
+				continue
+			}
+			if alias == "" || ch.Width < width {
+				alias = a
+				width = ch.Width
+			}
+		}
+		if alias == "" {
+			return "", xerrors.Errorf("%s: %w", camera.Name, ErrRTSPDisabled)
+		}
+
+		port := b.Nvr.Ports.Rtsp
+		if port == 0 {
+			port = defaultRTSPPort
+		}
+		return fmt.Sprintf("rtsp://%s/%s", net.JoinHostPort(c.c.UnifiIp(), strconv.Itoa(port)), alias), nil
+	}
+
+	return "", xerrors.Errorf("doorbell %s: %w", doorbellID, ErrDoorbellNotFound)
+}
@@ -0,0 +1,187 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// IR LED modes of camera
+const (
+	IRModeAuto           = "auto"
+	IRModeOn             = "on"
+	IRModeOff            = "off"
+	IRModeAutoFilterOnly = "autoFilterOnly"
+)
+
+// IRModes is list of IR LED modes which can be set
+var IRModes = []string{IRModeAuto, IRModeOn, IRModeOff, IRModeAutoFilterOnly}
+
+// chime durations accepted by UniFi Protect in milliseconds.
+// 0 is no chime, 300 is mechanical chime and 1000 to 10000 are digital chime.
+const (
+	ChimeDurationNone       = 0
+	ChimeDurationMechanical = 300
+	ChimeDurationDigitalMin = 1000
+	ChimeDurationDigitalMax = 10000
+)
+
+// DoorbellSettings is the set of doorbell settings which can be changed.
+// Nil fields are left unchanged.
+type DoorbellSettings struct {
+	ChimeDurationMs *int    `json:"chime_duration_ms,omitempty"`
+	SpeakerVolume   *int    `json:"speaker_volume,omitempty"`
+	LEDEnabled      *bool   `json:"led_enabled,omitempty"`
+	IRMode          *string `json:"ir_mode,omitempty"`
+	OSDNameEnabled  *bool   `json:"osd_name_enabled,omitempty"`
+	OSDDateEnabled  *bool   `json:"osd_date_enabled,omitempty"`
+}
+
+// SettingsOf returns current settings of the doorbell. Settings which it doesn't support are nil.
+func SettingsOf(d *Doorbell) DoorbellSettings {
+	s := DoorbellSettings{
+		OSDNameEnabled: &d.OsdSettings.IsNameEnabled,
+		OSDDateEnabled: &d.OsdSettings.IsDateEnabled,
+	}
+	if d.FeatureFlags.HasChime {
+		s.ChimeDurationMs = &d.ChimeDuration
+	}
+	if d.FeatureFlags.HasSpeaker {
+		s.SpeakerVolume = &d.SpeakerSettings.Volume
+	}
+	if d.FeatureFlags.HasLedStatus {
+		s.LEDEnabled = &d.LedSettings.IsEnabled
+	}
+	if d.FeatureFlags.HasLedIr {
+		s.IRMode = &d.IspSettings.IrLedMode
+	}
+	return s
+}
+
+// SettingError is a setting rejected by validation
+type SettingError struct {
+	Field  string
+	Reason string
+}
+
+// SettingErrors is settings rejected by validation
+type SettingErrors []SettingError
+
+func (es SettingErrors) Error() string {
+	msgs := make([]string, 0, len(es))
+	for _, e := range es {
+		msgs = append(msgs, e.Field+": "+e.Reason)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (es *SettingErrors) add(field, format string, args ...interface{}) {
+	*es = append(*es, SettingError{Field: field, Reason: fmt.Sprintf(format, args...)})
+}
+
+// Validate rejects settings out of range or not supported by the doorbell according to its feature flags
+func (s DoorbellSettings) Validate(d *Doorbell) error {
+	var errs SettingErrors
+	flags := d.FeatureFlags
+
+	if v := s.ChimeDurationMs; v != nil {
+		switch {
+		case !flags.HasChime:
+			errs.add("chime_duration_ms", "%s has no chime", d.Name)
+		case *v != ChimeDurationNone && *v != ChimeDurationMechanical &&
+			(*v < ChimeDurationDigitalMin || *v > ChimeDurationDigitalMax):
+			errs.add("chime_duration_ms", "must be %d, %d or between %d and %d",
+				ChimeDurationNone, ChimeDurationMechanical, ChimeDurationDigitalMin, ChimeDurationDigitalMax)
+		}
+	}
+	if v := s.SpeakerVolume; v != nil {
+		switch {
+		case !flags.HasSpeaker:
+			errs.add("speaker_volume", "%s has no speaker", d.Name)
+		case *v < 0 || *v > 100:
+			errs.add("speaker_volume", "must be between 0 and 100")
+		}
+	}
+	if s.LEDEnabled != nil && !flags.HasLedStatus {
+		errs.add("led_enabled", "%s has no status LED", d.Name)
+	}
+	if v := s.IRMode; v != nil {
+		switch {
+		case !flags.HasLedIr:
+			errs.add("ir_mode", "%s has no IR LED", d.Name)
+		case !containsString(IRModes, *v):
+			errs.add("ir_mode", "must be one of %s", strings.Join(IRModes, ", "))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// patch returns request body of UniFi Protect to change the settings
+func (s DoorbellSettings) patch() map[string]interface{} {
+	p := make(map[string]interface{})
+	if s.ChimeDurationMs != nil {
+		p["chimeDuration"] = *s.ChimeDurationMs
+	}
+	if s.SpeakerVolume != nil {
+		p["speakerSettings"] = map[string]interface{}{"volume": *s.SpeakerVolume}
+	}
+	if s.LEDEnabled != nil {
+		p["ledSettings"] = map[string]interface{}{"isEnabled": *s.LEDEnabled}
+	}
+	if s.IRMode != nil {
+		p["ispSettings"] = map[string]interface{}{"irLedMode": *s.IRMode}
+	}
+
+	osd := make(map[string]interface{})
+	if s.OSDNameEnabled != nil {
+		osd["isNameEnabled"] = *s.OSDNameEnabled
+	}
+	if s.OSDDateEnabled != nil {
+		osd["isDateEnabled"] = *s.OSDDateEnabled
+	}
+	if len(osd) > 0 {
+		p["osdSettings"] = osd
+	}
+	return p
+}
+
+// UpdateDoorbellSettings validates settings against the doorbell and changes them.
+// It returns the doorbell updated by UniFi Protect.
+func (c *Client) UpdateDoorbellSettings(ctx context.Context, doorbellID string, s DoorbellSettings) (*Doorbell, error) {
+	d, err := c.cache.Doorbell(ctx, doorbellID, DefaultMaxAge)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to update settings: %w", err)
+	}
+	if err := s.Validate(d); err != nil {
+		return nil, xerrors.Errorf("failed to update settings of %s: %w", d.Name, err)
+	}
+
+	u := c.baseURL()
+	u.Path = "/api/cameras/" + doorbellID
+
+	var camera Camera
+	if err := c.jsonRequest(ctx, http.MethodPatch, u, s.patch(), &camera); err != nil {
+		return nil, xerrors.Errorf("failed to update settings of %s: %w", d.Name, err)
+	}
+	c.logger.Debugf("update settings of %s successfully", d.Name)
+
+	c.cache.update(camera)
+	updated := Doorbell(camera)
+	return &updated, nil
+}
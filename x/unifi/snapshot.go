@@ -8,16 +8,42 @@ import (
 	"golang.org/x/xerrors"
 )
 
+// ErrNoPackageCamera is returned if doorbell has no package camera
+var ErrNoPackageCamera = xerrors.New("doorbell has no package camera")
+
 func (c *Client) GetSnapshot(ctx context.Context, w io.Writer, doorbellID string) error {
+	if err := c.getSnapshot(ctx, w, "/api/cameras/"+doorbellID+"/snapshot"); err != nil {
+		return xerrors.Errorf("failed to get snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetPackageSnapshot writes snapshot of package camera of the doorbell like G4 Doorbell Pro
+func (c *Client) GetPackageSnapshot(ctx context.Context, w io.Writer, doorbellID string) error {
+	d, err := c.cache.Doorbell(ctx, doorbellID, DefaultMaxAge)
+	if err != nil {
+		return xerrors.Errorf("failed to get package snapshot: %w", err)
+	}
+	if !d.HasPackageCamera() {
+		return xerrors.Errorf("%s: %w", d.Name, ErrNoPackageCamera)
+	}
+
+	if err := c.getSnapshot(ctx, w, "/api/cameras/"+doorbellID+"/package-snapshot"); err != nil {
+		return xerrors.Errorf("failed to get package snapshot: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) getSnapshot(ctx context.Context, w io.Writer, path string) error {
 	u := c.baseURL()
-	u.Path = "/api/cameras/" + doorbellID + "/snapshot"
+	u.Path = path
 
 	timeoutCtx, cancelFunc := context.WithTimeout(ctx, defaultRequestTimeout)
 	defer cancelFunc()
 
 	res, err := c.request(timeoutCtx, http.MethodGet, u, nil)
 	if err != nil {
-		return xerrors.Errorf("failed to get snapshot: %w", err)
+		return err
 	}
 	defer func() {
 		if err := res.Body.Close(); err != nil {
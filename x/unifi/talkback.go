@@ -0,0 +1,61 @@
+package unifi
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"golang.org/x/xerrors"
+)
+
+var ErrTalkbackUnsupported = xerrors.New("talkback is not supported")
+
+// audio format of G4 Doorbell used if UniFi Protect doesn't tell it
+const (
+	defaultTalkbackSamplingRate = 22050
+	defaultTalkbackChannels     = 1
+)
+
+type TalkbackTarget struct {
+	Addr          string
+	Format        string
+	SamplingRate  int
+	Channels      int
+	BitsPerSample int
+}
+
+// GetTalkbackTarget returns the endpoint of the doorbell speaker and audio format which it accepts
+func (c *Client) GetTalkbackTarget(ctx context.Context, doorbellID string) (*TalkbackTarget, error) {
+	b, err := c.cache.Bootstrap(ctx, DefaultMaxAge)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get talkback target: %w", err)
+	}
+
+	for _, camera := range b.Cameras {
+		if camera.ID != doorbellID {
+			continue
+		}
+
+		ts := camera.TalkbackSettings
+		if !camera.FeatureFlags.HasSpeaker || ts.BindPort == 0 {
+			return nil, xerrors.Errorf("%s: %w", camera.Name, ErrTalkbackUnsupported)
+		}
+		t := &TalkbackTarget{
+			Addr:          net.JoinHostPort(camera.Host, strconv.Itoa(ts.BindPort)),
+			Format:        ts.TypeFmt,
+			SamplingRate:  ts.SamplingRate,
+			Channels:      ts.Channels,
+			BitsPerSample: ts.BitsPerSample,
+		}
+		if t.SamplingRate <= 0 {
+			c.logger.Warnf("sampling rate of talkback of %s is unknown. use %dHz", camera.Name, defaultTalkbackSamplingRate)
+			t.SamplingRate = defaultTalkbackSamplingRate
+		}
+		if t.Channels <= 0 {
+			t.Channels = defaultTalkbackChannels
+		}
+		return t, nil
+	}
+
+	return nil, xerrors.Errorf("doorbell %s: %w", doorbellID, ErrDoorbellNotFound)
+}
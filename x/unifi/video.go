@@ -0,0 +1,52 @@
+package unifi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+const videoExportTimeout = 2 * time.Minute
+
+// ExportVideo downloads recorded video of the camera between start and end as MP4
+func (c *Client) ExportVideo(ctx context.Context, w io.Writer, cameraID string, start, end time.Time) error {
+	u := c.baseURL()
+	u.Path = "/api/video/export"
+	q := u.Query()
+	q.Set("camera", cameraID)
+	q.Set("channel", "0")
+	q.Set("start", strconv.FormatInt(start.UnixNano()/int64(time.Millisecond), 10))
+	q.Set("end", strconv.FormatInt(end.UnixNano()/int64(time.Millisecond), 10))
+	u.RawQuery = q.Encode()
+
+	timeoutCtx, cancelFunc := context.WithTimeout(ctx, videoExportTimeout)
+	defer cancelFunc()
+
+	res, err := c.request(timeoutCtx, http.MethodGet, u, nil)
+	if err != nil {
+		return xerrors.Errorf("failed to export video: %w", err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			c.logger.Errorln(err)
+		}
+	}()
+
+	if res.StatusCode >= 300 {
+		return &HttpError{
+			message: res.Status,
+			code:    res.StatusCode,
+			url:     u,
+			method:  http.MethodGet,
+		}
+	}
+
+	if _, err := io.Copy(w, res.Body); err != nil {
+		return xerrors.Errorf("failed to download video: %w", err)
+	}
+	return nil
+}